@@ -0,0 +1,282 @@
+//Command petfinder queries the Petfinder API from the command line.
+//
+//Usage:
+//
+//	petfinder [-format table|json|csv|ndjson] [-columns names] [-config file] <command> [flags]
+//
+//Commands:
+//
+//	breeds        list breeds of an animal (breed.list)
+//	random        a random pet, its full record unless -output is given (pet.getRandom)
+//	pet get       a pet by ID (pet.get)
+//	pet find      search for pets near a location (pet.find)
+//	shelter find  search for shelters near a location (shelter.find)
+//	shelter get   a shelter by ID (shelter.get)
+//	shelter pets  pets of a shelter (shelter.getPets)
+//	shelter breed shelters with pets of a breed (shelter.listByBreed)
+//
+//Every command accepts the flags of petfinder.Options, e.g. -animal dog -location 94041.
+//Commands taking an ID also accept it as their only argument.
+//
+//With -columns, pets and shelters are printed in the table, csv and ndjson formats
+//as the named columns of the flat schema of the export package. The json format
+//always prints the petfinder types as they are.
+//
+//The API key is read from the PETFINDER_API_KEY environment variable, or else from
+//the "api_key" field of a JSON config file, by default petfinder/config.json in the
+//user's configuration directory.
+package main
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aouyang1/go-petfinder/petfinder"
+	"github.com/aouyang1/go-petfinder/petfinder/export"
+)
+
+//errUsage is returned for invalid command lines once usage has been printed
+var errUsage = errors.New("invalid usage")
+
+//cli holds the dependencies of the command so they can be replaced in tests
+type cli struct {
+	stdout    io.Writer
+	stderr    io.Writer
+	getenv    func(string) string
+	newClient func(apiKey string) petfinder.Client
+}
+
+func main() {
+	c := cli{
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		getenv: os.Getenv,
+		newClient: func(apiKey string) petfinder.Client {
+			return petfinder.NewClient(apiKey)
+		},
+	}
+	if err := c.run(context.Background(), os.Args[1:]); err != nil {
+		if err != errUsage {
+			fmt.Fprintln(os.Stderr, "petfinder:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+//command is a subcommand and the Client method it calls
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, c petfinder.Client, opt petfinder.Options) (interface{}, error)
+}
+
+var commands = []command{
+	{"breeds", "list breeds of an animal", func(ctx context.Context, c petfinder.Client, opt petfinder.Options) (interface{}, error) {
+		return c.ListBreedsContext(ctx, opt)
+	}},
+	{"random", "a random pet, only its ID with -output id", func(ctx context.Context, c petfinder.Client, opt petfinder.Options) (interface{}, error) {
+		switch opt.Output {
+		case petfinder.OutputID:
+			return c.GetRandomPetIDContext(ctx, opt)
+		case "":
+			opt.Output = petfinder.OutputFull
+		}
+		return c.GetRandomPetContext(ctx, opt)
+	}},
+	{"pet get", "a pet by ID", func(ctx context.Context, c petfinder.Client, opt petfinder.Options) (interface{}, error) {
+		return c.GetPetContext(ctx, opt)
+	}},
+	{"pet find", "search for pets near a location", func(ctx context.Context, c petfinder.Client, opt petfinder.Options) (interface{}, error) {
+		return c.FindPetContext(ctx, opt)
+	}},
+	{"shelter find", "search for shelters near a location", func(ctx context.Context, c petfinder.Client, opt petfinder.Options) (interface{}, error) {
+		return c.FindShelterContext(ctx, opt)
+	}},
+	{"shelter get", "a shelter by ID", func(ctx context.Context, c petfinder.Client, opt petfinder.Options) (interface{}, error) {
+		return c.GetShelterContext(ctx, opt)
+	}},
+	{"shelter pets", "pets of a shelter", func(ctx context.Context, c petfinder.Client, opt petfinder.Options) (interface{}, error) {
+		return c.GetShelterPetsContext(ctx, opt)
+	}},
+	{"shelter breed", "shelters with pets of a breed", func(ctx context.Context, c petfinder.Client, opt petfinder.Options) (interface{}, error) {
+		return c.ListSheltersByBreedContext(ctx, opt)
+	}},
+}
+
+//lookupCommand finds the command named by the leading arguments and returns the remaining ones
+func lookupCommand(args []string) (command, []string, bool) {
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == cmd.name {
+			return cmd, args[len(words):], true
+		}
+	}
+	return command{}, nil, false
+}
+
+func (c cli) usage(fs *flag.FlagSet) {
+	fmt.Fprintln(c.stderr, "usage: petfinder [flags] <command> [command flags]")
+	fmt.Fprintln(c.stderr, "\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(c.stderr, "  %-15s%s\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintln(c.stderr, "\nflags:")
+	fs.PrintDefaults()
+}
+
+func (c cli) run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("petfinder", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	format := fs.String("format", "table", "output `format`: table, json, csv or ndjson")
+	columns := fs.String("columns", "", "comma separated `columns` of pets and shelters to print, see the export package for names")
+	configPath := fs.String("config", "", "config `file` holding the API key (default petfinder/config.json in the user config directory)")
+	fs.Usage = func() { c.usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return usageErr(err)
+	}
+
+	w, ok := writers[*format]
+	if !ok {
+		fmt.Fprintf(c.stderr, "unknown format %q\n", *format)
+		return errUsage
+	}
+	if *columns != "" {
+		names := strings.Split(*columns, ",")
+		known := append(export.PetSchema().Names(), export.ShelterSchema().Names()...)
+		for _, name := range names {
+			if !containsString(known, name) {
+				fmt.Fprintf(c.stderr, "unknown column %q\n", name)
+				return errUsage
+			}
+		}
+		w = columnWriter(*format, names)
+	}
+	cmd, rest, ok := lookupCommand(fs.Args())
+	if !ok {
+		c.usage(fs)
+		return errUsage
+	}
+
+	var opt petfinder.Options
+	cmdFlags := optionFlags("petfinder "+cmd.name, &opt)
+	cmdFlags.SetOutput(c.stderr)
+	if err := cmdFlags.Parse(rest); err != nil {
+		return usageErr(err)
+	}
+	switch {
+	case cmdFlags.NArg() == 1 && opt.ID == "":
+		opt.ID = cmdFlags.Arg(0)
+	case cmdFlags.NArg() > 0:
+		fmt.Fprintf(c.stderr, "unexpected arguments %q\n", cmdFlags.Args())
+		return errUsage
+	}
+
+	apiKey, err := c.apiKey(*configPath)
+	if err != nil {
+		return err
+	}
+	result, err := cmd.run(ctx, c.newClient(apiKey), opt)
+	if err != nil {
+		return err
+	}
+	return w(c.stdout, result)
+}
+
+func containsString(s []string, v string) bool {
+	for _, str := range s {
+		if str == v {
+			return true
+		}
+	}
+	return false
+}
+
+//usageErr maps a flag parsing error to errUsage, treating a request for help as success
+func usageErr(err error) error {
+	if err == flag.ErrHelp {
+		return nil
+	}
+	return errUsage
+}
+
+//optionFlags returns a flag set mirroring petfinder.Options
+func optionFlags(name string, opt *petfinder.Options) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.StringVar(&opt.ID, "id", "", "pet or shelter `ID`")
+	fs.Var(textFlag{&opt.Animal}, "animal", "`animal`: barnyard, bird, cat, dog, horse, reptile or smallfurry")
+	fs.StringVar(&opt.Breed, "breed", "", "`breed` name as listed by the breeds command")
+	fs.Var(textFlag{&opt.Size}, "size", "`size`: S, M, L or XL")
+	fs.Var(textFlag{&opt.Sex}, "sex", "`sex`: M or F")
+	fs.StringVar(&opt.Location, "location", "", "zip code or city, state")
+	fs.Var(textFlag{&opt.Age}, "age", "`age`: Baby, Young, Adult or Senior")
+	fs.IntVar(&opt.Offset, "offset", 0, "offset of the first result")
+	fs.IntVar(&opt.Count, "count", 0, "number of results")
+	fs.Var(textFlag{&opt.Output}, "output", "output `level`: basic, full or id")
+	fs.StringVar(&opt.ShelterID, "shelter-id", "", "shelter `ID`")
+	fs.StringVar(&opt.ShelterName, "name", "", "shelter `name`")
+	fs.Var(textFlag{&opt.Status}, "status", "pet `status`: A, H, P or X")
+	return fs
+}
+
+//textFlag adapts the option enums, which implement encoding.TextUnmarshaler, to flag.Value
+type textFlag struct {
+	v interface {
+		encoding.TextMarshaler
+		encoding.TextUnmarshaler
+	}
+}
+
+func (f textFlag) String() string {
+	if f.v == nil {
+		return ""
+	}
+	text, _ := f.v.MarshalText()
+	return string(text)
+}
+
+func (f textFlag) Set(s string) error {
+	return f.v.UnmarshalText([]byte(s))
+}
+
+//apiKey reads the API key from the environment or the config file
+func (c cli) apiKey(configPath string) (string, error) {
+	if key := strings.TrimSpace(c.getenv("PETFINDER_API_KEY")); key != "" {
+		return key, nil
+	}
+
+	explicit := configPath != ""
+	if !explicit {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", errors.New("No API key, set PETFINDER_API_KEY")
+		}
+		configPath = filepath.Join(dir, "petfinder", "config.json")
+	}
+	buf, err := ioutil.ReadFile(configPath)
+	if os.IsNotExist(err) && !explicit {
+		return "", fmt.Errorf("No API key, set PETFINDER_API_KEY or api_key in %s", configPath)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var config struct {
+		APIKey string `json:"api_key"`
+	}
+	if err = json.Unmarshal(buf, &config); err != nil {
+		return "", fmt.Errorf("Invalid config file %s: %v", configPath, err)
+	}
+	if config.APIKey == "" {
+		return "", fmt.Errorf("No api_key in %s", configPath)
+	}
+	return config.APIKey, nil
+}
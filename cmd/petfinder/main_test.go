@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aouyang1/go-petfinder/petfinder"
+	"github.com/aouyang1/go-petfinder/petfinder/petfindertest"
+)
+
+func newTestCLI(t *testing.T, env map[string]string) (cli, *bytes.Buffer, *petfindertest.Server) {
+	srv := petfindertest.NewServer()
+	t.Cleanup(srv.Close)
+
+	var stdout bytes.Buffer
+	c := cli{
+		stdout: &stdout,
+		stderr: ioutil.Discard,
+		getenv: func(name string) string { return env[name] },
+		newClient: func(apiKey string) petfinder.Client {
+			return petfinder.NewClient(apiKey, petfinder.WithHTTPClient(srv.HTTPClient()))
+		},
+	}
+	return c, &stdout, srv
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"breeds", "-animal", "dog"}, []string{"BREED", "Akita"}},
+		{[]string{"random", "-output", "id"}, []string{"ID"}},
+		{[]string{"random"}, []string{"ID", "39930101", "Biscuit"}},
+		{[]string{"pet", "get", "39930101"}, []string{"ID", "39930101", "Biscuit", "Labrador Retriever; Beagle"}},
+		{[]string{"-format", "csv", "pet", "find", "-location", "75024", "-animal", "dog"}, []string{"id,name,animal", "39930101,Biscuit,dog"}},
+		{[]string{"-format", "csv", "-columns", "id,name,altered", "pet", "find", "-location", "75024"}, []string{"id,name,altered\n39930101,Biscuit,true\n"}},
+		{[]string{"-columns", "name,no_cats", "pet", "get", "39930101"}, []string{"NAME     NO_CATS\nBiscuit  true"}},
+		{[]string{"-format", "ndjson", "-columns", "id,name", "shelter", "get", "TX1203"}, []string{`{"id":"TX1203","name":`}},
+		{[]string{"-columns", "id", "breeds", "-animal", "dog"}, []string{"BREED", "Akita"}},
+		{[]string{"shelter", "get", "-id", "TX1203"}, []string{"TX1203", "Plano"}},
+		{[]string{"-format", "json", "shelter", "find", "-location", "75024"}, []string{`"ID": "TX1203"`}},
+		{[]string{"shelter", "pets", "TX1203"}, []string{"39930101"}},
+		{[]string{"shelter", "breed", "-animal", "dog", "-breed", "Beagle"}, []string{"TX1203"}},
+	}
+	for _, tt := range tests {
+		c, stdout, _ := newTestCLI(t, map[string]string{"PETFINDER_API_KEY": "key"})
+		if err := c.run(context.Background(), tt.args); err != nil {
+			t.Errorf("%v: %v", tt.args, err)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(stdout.String(), want) {
+				t.Errorf("%v: expected output to contain %q, got\n%s", tt.args, want, stdout)
+			}
+		}
+	}
+}
+
+func TestRunNDJSON(t *testing.T) {
+	c, stdout, _ := newTestCLI(t, map[string]string{"PETFINDER_API_KEY": "key"})
+	if err := c.run(context.Background(), []string{"-format", "ndjson", "pet", "find", "-location", "75024"}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d", len(lines))
+	}
+	var pet map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[2]), &pet); err != nil || pet["ID"] != "39930103" {
+		t.Errorf("Unexpected last line %s, %v", lines[2], err)
+	}
+}
+
+func TestRunConfigFile(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.json")
+	if err := ioutil.WriteFile(config, []byte(`{"api_key": "filekey"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, _, srv := newTestCLI(t, nil)
+	if err := c.run(context.Background(), []string{"-config", config, "pet", "get", "39930101"}); err != nil {
+		t.Fatal(err)
+	}
+	if key := srv.Requests()[0].Query().Get("key"); key != "filekey" {
+		t.Errorf("Expected the key from the config file, got %q", key)
+	}
+
+	if err := c.run(context.Background(), []string{"-config", config + ".missing", "pet", "get", "1"}); err == nil {
+		t.Errorf("Expected an error for a missing config file")
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	tests := [][]string{
+		{},
+		{"pet"},
+		{"-format", "xml", "breeds"},
+		{"pet", "find", "-animal", "dinosaur"},
+		{"pet", "get", "1", "2"},
+		{"-columns", "colour", "pet", "get", "1"},
+	}
+	for _, args := range tests {
+		c, _, _ := newTestCLI(t, map[string]string{"PETFINDER_API_KEY": "key"})
+		if err := c.run(context.Background(), args); err != errUsage {
+			t.Errorf("%v: expected a usage error, got %v", args, err)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aouyang1/go-petfinder/petfinder"
+	"github.com/aouyang1/go-petfinder/petfinder/export"
+)
+
+//writers print a command result in each output format
+var writers = map[string]func(io.Writer, interface{}) error{
+	"table":  writeTable,
+	"json":   writeJSON,
+	"csv":    writeCSV,
+	"ndjson": writeNDJSON,
+}
+
+var (
+	petColumns     = []string{"id", "name", "animal", "breeds", "age", "sex", "size", "status", "shelter_id", "city", "state", "last_update"}
+	shelterColumns = []string{"id", "name", "city", "state", "zip", "country", "phone", "email"}
+)
+
+//records flattens a command result into a header and rows
+func records(v interface{}) ([]string, [][]string) {
+	switch v := v.(type) {
+	case string:
+		return []string{"id"}, [][]string{{v}}
+	case petfinder.Breeds:
+		rows := make([][]string, len(v))
+		for i, breed := range v {
+			rows[i] = []string{breed}
+		}
+		return []string{"breed"}, rows
+	case petfinder.Pet:
+		return petColumns, [][]string{petRow(v)}
+	case petfinder.Pets:
+		rows := make([][]string, len(v))
+		for i, pet := range v {
+			rows[i] = petRow(pet)
+		}
+		return petColumns, rows
+	case petfinder.Shelter:
+		return shelterColumns, [][]string{shelterRow(v)}
+	case petfinder.Shelters:
+		rows := make([][]string, len(v))
+		for i, shelter := range v {
+			rows[i] = shelterRow(shelter)
+		}
+		return shelterColumns, rows
+	}
+	panic(fmt.Sprintf("petfinder: no columns for %T", v))
+}
+
+func petRow(p petfinder.Pet) []string {
+	var updated string
+	if !p.LastUpdate.IsZero() {
+		updated = p.LastUpdate.Format(time.RFC3339)
+	}
+	return []string{
+		p.ID, p.Name, p.Animal.String(), strings.Join(p.Breeds, "; "), p.Age.String(), p.Sex.String(),
+		p.Size.String(), p.Status.String(), p.ShelterID, p.Contact.City, p.Contact.State, updated,
+	}
+}
+
+func shelterRow(s petfinder.Shelter) []string {
+	return []string{s.ID, s.Name, s.City, s.State, s.Zip, s.Country, s.Phone, s.Email}
+}
+
+func writeTable(w io.Writer, v interface{}) error {
+	header, rows := records(v)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func writeCSV(w io.Writer, v interface{}) error {
+	header, rows := records(v)
+	cw := csv.NewWriter(w)
+	cw.Write(header)
+	cw.WriteAll(rows)
+	return cw.Error()
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+//writeNDJSON writes each element of a list result on its own line
+func writeNDJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	var err error
+	switch v := v.(type) {
+	case petfinder.Breeds:
+		for i := 0; i < len(v) && err == nil; i++ {
+			err = enc.Encode(v[i])
+		}
+	case petfinder.Pets:
+		for i := 0; i < len(v) && err == nil; i++ {
+			err = enc.Encode(v[i])
+		}
+	case petfinder.Shelters:
+		for i := 0; i < len(v) && err == nil; i++ {
+			err = enc.Encode(v[i])
+		}
+	default:
+		err = enc.Encode(v)
+	}
+	return err
+}
+
+//columnWriter prints the selected export schema columns of pets and shelters in the
+//table, csv and ndjson formats, and any other result as the format's writer does
+func columnWriter(format string, columns []string) func(io.Writer, interface{}) error {
+	write := writers[format]
+	if format == "json" {
+		return write
+	}
+	return func(w io.Writer, v interface{}) error {
+		schema, items, ok := exportRecords(v)
+		if !ok {
+			return write(w, v)
+		}
+		schema, err := schema.Select(columns...)
+		if err != nil {
+			return err
+		}
+
+		var ew export.Writer
+		switch format {
+		case "csv":
+			ew = export.NewCSVWriter(w, schema)
+		case "ndjson":
+			ew = export.NewNDJSONWriter(w, schema)
+		default:
+			return writeSchemaTable(w, schema, items)
+		}
+		for _, item := range items {
+			if err = ew.Write(item); err != nil {
+				return err
+			}
+		}
+		return ew.Flush()
+	}
+}
+
+//exportRecords returns the export schema and items of a result, false if it is neither pets nor shelters
+func exportRecords(v interface{}) (export.Schema, []interface{}, bool) {
+	var items []interface{}
+	switch v := v.(type) {
+	case petfinder.Pet:
+		return export.PetSchema(), []interface{}{v}, true
+	case petfinder.Pets:
+		for _, pet := range v {
+			items = append(items, pet)
+		}
+		return export.PetSchema(), items, true
+	case petfinder.Shelter:
+		return export.ShelterSchema(), []interface{}{v}, true
+	case petfinder.Shelters:
+		for _, shelter := range v {
+			items = append(items, shelter)
+		}
+		return export.ShelterSchema(), items, true
+	}
+	return export.Schema{}, nil, false
+}
+
+//writeSchemaTable aligns records in columns, encoding values as in CSV
+func writeSchemaTable(w io.Writer, schema export.Schema, items []interface{}) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(schema.Names(), "\t")))
+	for _, item := range items {
+		row, err := schema.StringRecord(item)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
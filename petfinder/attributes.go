@@ -0,0 +1,106 @@
+package petfinder
+
+import (
+	"strings"
+)
+
+//Attributes are the option codes of a pet parsed into flags.
+//The API only reports options that apply, so a false flag means the shelter did not set it.
+type Attributes struct {
+	Altered      bool     // spayed or neutered, "altered"
+	HasShots     bool     // vaccinations current, "hasShots"
+	Housetrained bool     // "housetrained"
+	NoClaws      bool     // declawed, "noClaws"
+	NoCats       bool     // not good with cats, "noCats"
+	NoDogs       bool     // not good with dogs, "noDogs"
+	NoKids       bool     // not good with children, "noKids"
+	SpecialNeeds bool     // "specialNeeds"
+	Unknown      []string // option codes not recognised, as reported
+}
+
+//ParseAttributes parses option codes, ignoring case and surrounding whitespace
+func ParseAttributes(options []string) Attributes {
+	var a Attributes
+	for _, option := range options {
+		switch strings.ToLower(strings.TrimSpace(option)) {
+		case "altered":
+			a.Altered = true
+		case "hasshots":
+			a.HasShots = true
+		case "housetrained":
+			a.Housetrained = true
+		case "noclaws":
+			a.NoClaws = true
+		case "nocats":
+			a.NoCats = true
+		case "nodogs":
+			a.NoDogs = true
+		case "nokids":
+			a.NoKids = true
+		case "specialneeds":
+			a.SpecialNeeds = true
+		case "":
+		default:
+			a.Unknown = append(a.Unknown, option)
+		}
+	}
+	return a
+}
+
+//Predicate reports whether a pet matches a condition
+type Predicate func(Pet) bool
+
+//Filter returns the pets matching every predicate, in their original order
+func (p Pets) Filter(preds ...Predicate) Pets {
+	var matched Pets
+	for _, pet := range p {
+		if matchAll(pet, preds) {
+			matched = append(matched, pet)
+		}
+	}
+	return matched
+}
+
+func matchAll(pet Pet, preds []Predicate) bool {
+	for _, pred := range preds {
+		if !pred(pet) {
+			return false
+		}
+	}
+	return true
+}
+
+//GoodWithKids matches pets not marked as unsuitable for children
+func GoodWithKids() Predicate {
+	return func(p Pet) bool { return !p.Attributes.NoKids }
+}
+
+//GoodWithCats matches pets not marked as unsuitable for homes with cats
+func GoodWithCats() Predicate {
+	return func(p Pet) bool { return !p.Attributes.NoCats }
+}
+
+//GoodWithDogs matches pets not marked as unsuitable for homes with dogs
+func GoodWithDogs() Predicate {
+	return func(p Pet) bool { return !p.Attributes.NoDogs }
+}
+
+//Housetrained matches housetrained pets
+func Housetrained() Predicate {
+	return func(p Pet) bool { return p.Attributes.Housetrained }
+}
+
+//Altered matches spayed or neutered pets
+func Altered() Predicate {
+	return func(p Pet) bool { return p.Attributes.Altered }
+}
+
+//HasShots matches pets with current vaccinations
+func HasShots() Predicate {
+	return func(p Pet) bool { return p.Attributes.HasShots }
+}
+
+//SpecialNeeds matches pets with special needs
+func SpecialNeeds() Predicate {
+	return func(p Pet) bool { return p.Attributes.SpecialNeeds }
+}
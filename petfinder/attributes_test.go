@@ -0,0 +1,45 @@
+package petfinder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAttributes(t *testing.T) {
+	a := ParseAttributes([]string{"altered", "HasShots", " noKids ", "noClaws", "goodWithBirds", ""})
+	want := Attributes{Altered: true, HasShots: true, NoKids: true, NoClaws: true, Unknown: []string{"goodWithBirds"}}
+	if !reflect.DeepEqual(a, want) {
+		t.Errorf("Unexpected attributes %+v", a)
+	}
+}
+
+func TestFixturePetsFilter(t *testing.T) {
+	c, _ := newFixtureClient(t)
+	pets, err := c.FindPet(Options{Location: "75024"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pets[0].Attributes.Housetrained || !pets[0].Attributes.NoCats || pets[0].Attributes.NoKids {
+		t.Errorf("Unexpected attributes %+v", pets[0].Attributes)
+	}
+
+	tests := []struct {
+		preds []Predicate
+		ids   []string
+	}{
+		{nil, []string{"39930101", "39930102", "39930103"}},
+		{[]Predicate{Altered()}, []string{"39930101", "39930102"}},
+		{[]Predicate{Altered(), GoodWithCats()}, []string{"39930102"}},
+		{[]Predicate{GoodWithKids(), Housetrained()}, []string{"39930101"}},
+		{[]Predicate{SpecialNeeds()}, nil},
+	}
+	for i, tt := range tests {
+		var ids []string
+		for _, pet := range pets.Filter(tt.preds...) {
+			ids = append(ids, pet.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Errorf("%d: expected %v, got %v", i, tt.ids, ids)
+		}
+	}
+}
@@ -7,10 +7,8 @@ import (
 type breedListResponse struct {
 	Petfinder struct {
 		Breeds struct {
-			Breed []struct {
-				T string `json:"$t"`
-			} `json:"breed"`
-			Animal string `json:"@animal"`
+			Breed  textList `json:"breed"`
+			Animal string   `json:"@animal"`
 		} `json:"breeds"`
 		Header header `json:"header"`
 	} `json:"petfinder"`
@@ -27,8 +25,6 @@ func (b *Breeds) UnmarshalJSON(buf []byte) error {
 		return err
 	}
 
-	for _, breed := range breedList.Petfinder.Breeds.Breed {
-		*b = append(*b, breed.T)
-	}
+	*b = append(*b, breedList.Petfinder.Breeds.Breed...)
 	return nil
 }
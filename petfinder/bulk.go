@@ -0,0 +1,93 @@
+package petfinder
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+//defaultWorkers is the number of concurrent requests made by GetPets unless configured
+const defaultWorkers = 4
+
+//GetPetsOptions configures GetPets
+type GetPetsOptions struct {
+	Workers int // number of concurrent requests, defaults to 4
+}
+
+//GetPetsResult holds the outcome of GetPets for every requested ID
+type GetPetsResult struct {
+	Pets    []*Pet           // in the order of the requested IDs, nil where the pet could not be retrieved
+	Missing []string         // IDs the API reported as not found, in request order without duplicates
+	Errors  map[string]error // IDs that failed for any other reason
+}
+
+//GetPets retrieves many pets by ID concurrently. Requests go through the client's
+//rate limiter and retry policy like any other call. Each distinct ID is requested once.
+//Pets the API reports as not found are listed in Missing rather than Errors; HTTP
+//failures such as a 404 from a misconfigured base URL are always listed in Errors.
+//The returned error is only set when the context ended before every pet was retrieved.
+func (c Client) GetPets(ctx context.Context, ids []string, opt GetPetsOptions) (GetPetsResult, error) {
+	workers := opt.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+
+	var unique []string
+	seen := make(map[string]int, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; !ok {
+			seen[id] = len(unique)
+			unique = append(unique, id)
+		}
+	}
+
+	pets := make([]*Pet, len(unique))
+	errs := make([]error, len(unique))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				pet, err := c.GetPetContext(ctx, Options{ID: unique[i]})
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				pets[i] = &pet
+			}
+		}()
+	}
+
+feed:
+	for i := range unique {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			for j := i; j < len(unique); j++ {
+				errs[j] = ctx.Err()
+			}
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	result := GetPetsResult{Pets: make([]*Pet, len(ids)), Errors: make(map[string]error)}
+	for i, id := range ids {
+		result.Pets[i] = pets[seen[id]]
+	}
+	for i, err := range errs {
+		var apiErr *APIError
+		switch {
+		case err == nil:
+		case errors.As(err, &apiErr) && apiErr.Code == StatusNotFound:
+			result.Missing = append(result.Missing, unique[i])
+		default:
+			result.Errors[unique[i]] = err
+		}
+	}
+	return result, ctx.Err()
+}
@@ -0,0 +1,79 @@
+package petfinder
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetPets(t *testing.T) {
+	var inFlight, maxInFlight, requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		id := r.URL.Query().Get("id")
+		switch id {
+		case "gone":
+			fmt.Fprint(w, `{"petfinder":{"header":{"status":{"code":{"$t":"201"}}}}}`)
+		case "broken":
+			w.WriteHeader(http.StatusBadRequest)
+		case "proxy":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			fmt.Fprintf(w, `{"petfinder":{"pet":{"id":{"$t":"%s"}},"header":{"status":{"code":{"$t":"100"}}}}}`, id)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient("key", WithBaseURL(ts.URL), WithInsecureHTTP())
+	ids := []string{"1", "gone", "2", "broken", "3", "4", "5", "proxy", "gone", "2"}
+	result, err := c.GetPets(context.Background(), ids, GetPetsOptions{Workers: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Pets) != len(ids) {
+		t.Fatalf("Expected %d results, got %d", len(ids), len(result.Pets))
+	}
+	for i, id := range ids {
+		pet := result.Pets[i]
+		switch id {
+		case "gone", "broken", "proxy":
+			if pet != nil {
+				t.Errorf("Expected no pet for %s", id)
+			}
+		default:
+			if pet == nil || pet.ID != id {
+				t.Errorf("Expected pet %s at index %d, got %+v", id, i, pet)
+			}
+		}
+	}
+	if len(result.Missing) != 1 || result.Missing[0] != "gone" {
+		t.Errorf("Unexpected missing pets %v", result.Missing)
+	}
+	if len(result.Errors) != 2 || result.Errors["broken"] == nil || result.Errors["proxy"] == nil {
+		t.Errorf("Unexpected errors %v", result.Errors)
+	}
+	if requests != 8 {
+		t.Errorf("Expected each distinct ID to be requested once, got %d requests", requests)
+	}
+	if result.Pets[1] != nil || result.Pets[9] == nil || result.Pets[9] != result.Pets[2] {
+		t.Errorf("Expected duplicate IDs to share a result")
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+}
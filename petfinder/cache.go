@@ -0,0 +1,226 @@
+package petfinder
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//Cache stores raw API responses for a limited time. Implementations must be safe for concurrent use.
+type Cache interface {
+	//Get returns the value stored under key if it has not expired
+	Get(key string) ([]byte, bool)
+	//Set stores value under key for the given time to live
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+//DefaultCacheTTLs returns how long responses of each API method are cached when no TTLs are given.
+//Methods that are not listed, such as pet.getRandom, are never cached.
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"breed.list":          24 * time.Hour,
+		"shelter.get":         time.Hour,
+		"shelter.find":        time.Hour,
+		"shelter.listByBreed": time.Hour,
+		"pet.get":             10 * time.Minute,
+		"pet.find":            10 * time.Minute,
+		"shelter.getPets":     10 * time.Minute,
+	}
+}
+
+//CacheStats counts lookups of cacheable requests
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+//responseCache wires a Cache into a Client, shared by copies of the Client
+type responseCache struct {
+	cache  Cache
+	ttls   map[string]time.Duration
+	hits   uint64
+	misses uint64
+}
+
+//WithCache caches successful responses per API method for the given TTLs, or DefaultCacheTTLs if ttls is nil.
+//Cache keys are built from the endpoint URL and the encoded options, never the API key,
+//so clients with different base URLs can share a Cache.
+func WithCache(cache Cache, ttls map[string]time.Duration) ClientOption {
+	return func(c *Client) {
+		if cache == nil {
+			return
+		}
+		if ttls == nil {
+			ttls = DefaultCacheTTLs()
+		}
+		c.cache = &responseCache{cache: cache, ttls: ttls}
+	}
+}
+
+//CacheStats reports the cache hits and misses of the client, zero if it has no cache
+func (c Client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+	return CacheStats{
+		Hits:   atomic.LoadUint64(&c.cache.hits),
+		Misses: atomic.LoadUint64(&c.cache.misses),
+	}
+}
+
+//ttl returns how long responses of the API method are cached, zero if they are not
+func (rc *responseCache) ttl(apiMethod string) time.Duration {
+	if rc == nil {
+		return 0
+	}
+	return rc.ttls[apiMethod]
+}
+
+func (rc *responseCache) get(key string) ([]byte, bool) {
+	body, ok := rc.cache.Get(key)
+	if ok {
+		atomic.AddUint64(&rc.hits, 1)
+	} else {
+		atomic.AddUint64(&rc.misses, 1)
+	}
+	return body, ok
+}
+
+//LRUCache is an in-memory Cache evicting the least recently used entry once full
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+	now      func() time.Time
+}
+
+type lruEntry struct {
+	key    string
+	value  []byte
+	expiry time.Time
+}
+
+//NewLRUCache creates an in-memory cache holding at most capacity responses
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+//Get returns the value stored under key if it has not expired
+func (l *LRUCache) Get(key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*lruEntry)
+	if !l.now().Before(entry.expiry) {
+		l.order.Remove(elem)
+		delete(l.entries, key)
+		return nil, false
+	}
+	l.order.MoveToFront(elem)
+	return append([]byte(nil), entry.value...), true
+}
+
+//Set stores value under key for the given time to live, evicting the least recently used entry if full
+func (l *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	value = append([]byte(nil), value...)
+	expiry := l.now().Add(ttl)
+	if elem, ok := l.entries[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.value, entry.expiry = value, expiry
+		l.order.MoveToFront(elem)
+		return
+	}
+
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, value: value, expiry: expiry})
+	for l.capacity > 0 && l.order.Len() > l.capacity {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+//Len returns the number of entries held, including expired ones not yet evicted
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+//FileCache is a Cache storing each response as a file in a directory, so it survives restarts
+type FileCache struct {
+	dir string
+	now func() time.Time
+}
+
+//NewFileCache creates a file backed cache in dir, creating the directory if needed
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir, now: time.Now}, nil
+}
+
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+".cache")
+}
+
+//Get returns the value stored under key if it has not expired
+func (f *FileCache) Get(key string) ([]byte, bool) {
+	path := f.path(key)
+	buf, err := ioutil.ReadFile(path)
+	if err != nil || len(buf) < 8 {
+		return nil, false
+	}
+
+	// files start with the expiry in unix nanoseconds
+	expiry := time.Unix(0, int64(binary.BigEndian.Uint64(buf[:8])))
+	if !f.now().Before(expiry) {
+		os.Remove(path)
+		return nil, false
+	}
+	return buf[8:], true
+}
+
+//Set stores value under key for the given time to live. Write failures are ignored
+//since a missing entry only costs another request.
+func (f *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	buf := make([]byte, 8, 8+len(value))
+	binary.BigEndian.PutUint64(buf, uint64(f.now().Add(ttl).UnixNano()))
+	buf = append(buf, value...)
+
+	tmp, err := ioutil.TempFile(f.dir, ".tmp-")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(buf)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), f.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
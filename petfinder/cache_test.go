@@ -0,0 +1,158 @@
+package petfinder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+//recordingCache is an unbounded cache remembering every key it was given
+type recordingCache struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (r *recordingCache) Get(key string) ([]byte, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := r.data[key]
+	return v, ok
+}
+
+func (r *recordingCache) Set(key string, value []byte, ttl time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.data[key] = value
+}
+
+func TestClientCache(t *testing.T) {
+	cache := &recordingCache{data: make(map[string][]byte)}
+	_, srv := newFixtureClient(t)
+	c := NewClient("secret", WithBaseURL(srv.URL), WithInsecureHTTP(), WithCache(cache, nil))
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.ListBreeds(Options{Animal: "dog"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.GetRandomPetID(Options{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := len(srv.Requests()); n != 4 {
+		t.Errorf("Expected 1 breed.list and 3 pet.getRandom requests, got %d", n)
+	}
+	if stats := c.CacheStats(); stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("Unexpected cache stats %+v", stats)
+	}
+	for key := range cache.data {
+		if !strings.HasPrefix(key, srv.BaseURL()+"breed.list?") || strings.Contains(key, "secret") {
+			t.Errorf("Unexpected cache key %s", key)
+		}
+	}
+
+	// errors are not cached
+	srv.SetFixture("breed.list", "error.limit")
+	if _, err := c.ListBreeds(Options{Animal: "cat"}); err == nil {
+		t.Fatal("Expected error")
+	}
+	if len(cache.data) != 1 {
+		t.Errorf("Expected failed response not to be cached")
+	}
+}
+
+func TestCacheSkipsInvalidResponses(t *testing.T) {
+	cache := &recordingCache{data: make(map[string][]byte)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>Service Unavailable</html>"))
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", WithBaseURL(srv.URL), WithInsecureHTTP(), WithCache(cache, nil))
+	if _, err := c.ListBreeds(Options{Animal: "dog"}); err == nil {
+		t.Fatal("Expected error")
+	}
+	if len(cache.data) != 0 {
+		t.Errorf("Expected a response without a valid header not to be cached, got %d entries", len(cache.data))
+	}
+}
+
+func TestNilCache(t *testing.T) {
+	_, srv := newFixtureClient(t)
+	c := NewClient("key", WithBaseURL(srv.URL), WithInsecureHTTP(), WithCache(nil, nil))
+	if _, err := c.ListBreeds(Options{Animal: "dog"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCacheSharedAcrossEndpoints(t *testing.T) {
+	cache := NewLRUCache(10)
+	_, srvA := newFixtureClient(t)
+	_, srvB := newFixtureClient(t)
+	srvB.SetFixture("breed.list", "error.limit")
+
+	a := NewClient("key", WithBaseURL(srvA.URL), WithInsecureHTTP(), WithCache(cache, nil))
+	b := NewClient("key", WithBaseURL(srvB.URL), WithInsecureHTTP(), WithCache(cache, nil))
+	if _, err := a.ListBreeds(Options{Animal: "dog"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.ListBreeds(Options{Animal: "dog"}); err == nil {
+		t.Errorf("Expected a response cached for another endpoint not to be served")
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	now := time.Now()
+	l := NewLRUCache(2)
+	l.now = func() time.Time { return now }
+
+	l.Set("a", []byte("1"), time.Minute)
+	l.Set("b", []byte("2"), time.Minute)
+	l.Get("a")
+	l.Set("c", []byte("3"), time.Hour)
+
+	if _, ok := l.Get("b"); ok {
+		t.Errorf("Expected least recently used entry to be evicted")
+	}
+	if v, ok := l.Get("a"); !ok || string(v) != "1" {
+		t.Errorf("Expected a to be cached, got %q %v", v, ok)
+	} else {
+		v[0] = 'x'
+	}
+	if v, _ := l.Get("a"); string(v) != "1" {
+		t.Errorf("Expected the cached value to be unaffected by callers, got %q", v)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := l.Get("a"); ok {
+		t.Errorf("Expected a to expire")
+	}
+	if _, ok := l.Get("c"); !ok || l.Len() != 1 {
+		t.Errorf("Expected only c to remain, got %d entries", l.Len())
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	f, err := NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	f.now = func() time.Time { return now }
+
+	f.Set("shelter.get?id=TX1203", []byte(`{"petfinder":{}}`), time.Hour)
+	if v, ok := f.Get("shelter.get?id=TX1203"); !ok || string(v) != `{"petfinder":{}}` {
+		t.Errorf("Unexpected cached value %q %v", v, ok)
+	}
+	if _, ok := f.Get("shelter.get?id=TX1577"); ok {
+		t.Errorf("Expected miss for unknown key")
+	}
+
+	now = now.Add(time.Hour)
+	if _, ok := f.Get("shelter.get?id=TX1203"); ok {
+		t.Errorf("Expected entry to expire")
+	}
+}
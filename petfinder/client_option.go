@@ -0,0 +1,76 @@
+package petfinder
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+//defaultUserAgent is sent with every request unless overridden with WithUserAgent
+const defaultUserAgent = "go-petfinder"
+
+//ClientOption configures a Client created by NewClient
+type ClientOption func(*Client)
+
+//WithBaseURL sends requests to a different API endpoint, e.g. a staging proxy or a local fake.
+//Plain HTTP endpoints are refused unless WithInsecureHTTP is also given.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		c.baseURL = baseURL
+	}
+}
+
+//WithInsecureHTTP allows the API key to be sent over plain HTTP
+func WithInsecureHTTP() ClientOption {
+	return func(c *Client) {
+		c.allowInsecure = true
+	}
+}
+
+//WithHTTPClient uses the given HTTP client to submit requests. A nil client keeps the default.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.HTTPClient = httpClient
+		}
+	}
+}
+
+//WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+//WithTimeout limits the time of each attempt, including reading the response body.
+//The HTTP client is copied so a client passed to WithHTTPClient is not modified.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+//WithRetryPolicy replaces the DefaultRetryPolicy
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.RetryPolicy = policy
+	}
+}
+
+//WithRateLimiter limits the rate of requests with a limiter that may be shared with other clients
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.RateLimiter = limiter
+	}
+}
+
+//WithLogger sends structured request events to the logger
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client) {
+		c.Logger = logger
+	}
+}
@@ -0,0 +1,279 @@
+package petfinder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSubmitRequestContextCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	c := NewClient("key", WithBaseURL(ts.URL), WithInsecureHTTP())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.GetPetContext(ctx, Options{ID: "1"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Request was not abandoned promptly, took %v", elapsed)
+	}
+}
+
+func TestSubmitRequestRetryPolicy(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			fmt.Fprint(w, `{"petfinder":{"header":{"status":{"code":{"$t":"999"}}}}}`)
+		default:
+			fmt.Fprint(w, `{"petfinder":{"pet":{"id":{"$t":"42"}},"header":{"status":{"code":{"$t":"100"}}}}}`)
+		}
+	}))
+	defer ts.Close()
+
+	var retries []RetryEvent
+	c := NewClient("key", WithBaseURL(ts.URL), WithInsecureHTTP())
+	c.RetryPolicy.BaseWait = time.Millisecond
+	c.RetryPolicy.OnRetry = func(e RetryEvent) {
+		retries = append(retries, e)
+	}
+
+	pet, err := c.GetPet(Options{ID: "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pet.ID != "42" {
+		t.Errorf("Expected pet 42, got %+v", pet)
+	}
+	if len(retries) != 2 {
+		t.Fatalf("Expected 2 retries, got %d", len(retries))
+	}
+	var httpErr *HTTPError
+	if !errors.As(retries[0].Err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected first retry on HTTP 503, got %v", retries[0].Err)
+	}
+	var apiErr *APIError
+	if !errors.As(retries[1].Err, &apiErr) || apiErr.Code != StatusInternal {
+		t.Errorf("Expected second retry on API status 999, got %v", retries[1].Err)
+	}
+}
+
+func TestSubmitRequestNotRetryable(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c := NewClient("key", WithBaseURL(ts.URL), WithInsecureHTTP())
+
+	_, err := c.GetPet(Options{ID: "42"})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected a single request, got %d", requests)
+	}
+}
+
+func TestRetryPolicyBackoffJitter(t *testing.T) {
+	p := RetryPolicy{BaseWait: time.Second, MaxWait: 4 * time.Second, Jitter: 1}
+	for retry := 0; retry < 5; retry++ {
+		for i := 0; i < 100; i++ {
+			if wait := p.Backoff(retry); wait < 0 || wait > p.MaxWait {
+				t.Fatalf("Backoff(%d) = %v, expected at most %v", retry, wait, p.MaxWait)
+			}
+		}
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	tests := []struct {
+		policy     RetryPolicy
+		retryAfter time.Duration
+		want       time.Duration
+	}{
+		{RetryPolicy{BaseWait: time.Second, MaxWait: 5 * time.Second}, 0, time.Second},
+		{RetryPolicy{BaseWait: time.Second, MaxWait: 5 * time.Second}, 3 * time.Second, 3 * time.Second},
+		{RetryPolicy{BaseWait: time.Second, MaxWait: 5 * time.Second}, 24 * time.Hour, 5 * time.Second},
+		{RetryPolicy{BaseWait: time.Second, MaxWait: 5 * time.Second, MaxRetryAfter: time.Minute}, 24 * time.Hour, time.Minute},
+		{DefaultRetryPolicy(), 30 * time.Second, 30 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.Delay(0, tt.retryAfter); got != tt.want {
+			t.Errorf("Delay(0, %v) = %v, expected %v", tt.retryAfter, got, tt.want)
+		}
+	}
+}
+
+func TestSubmitRequestRetryAfterCapped(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "86400")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"petfinder":{"pet":{"id":{"$t":"42"}},"header":{"status":{"code":{"$t":"100"}}}}}`)
+	}))
+	defer ts.Close()
+
+	var waits []time.Duration
+	c := NewClient("key", WithBaseURL(ts.URL), WithInsecureHTTP())
+	c.RetryPolicy.MaxRetryAfter = 10 * time.Millisecond
+	c.RetryPolicy.OnRetry = func(e RetryEvent) {
+		waits = append(waits, e.Wait)
+	}
+
+	if _, err := c.GetPet(Options{ID: "42"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(waits) != 1 || waits[0] != 10*time.Millisecond {
+		t.Errorf("Expected a single retry capped at 10ms, got %v", waits)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"-1", 0},
+		{now.Add(time.Minute).Format(http.TimeFormat), time.Minute},
+		{"garbage", 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, expected %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSubmitRequestLogger(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"petfinder":{"pet":{"id":{"$t":[]}},"header":{"status":{"code":{"$t":"100"}}}}}`)
+	}))
+	defer ts.Close()
+
+	var events []LogEvent
+	c := NewClient("secret", WithBaseURL(ts.URL), WithInsecureHTTP())
+	c.Logger = LoggerFunc(func(ctx context.Context, e LogEvent) {
+		events = append(events, e)
+	})
+
+	if _, err := c.GetPet(Options{ID: "42"}); err == nil {
+		t.Fatal("Expected decode error")
+	}
+
+	var kinds []EventKind
+	for _, e := range events {
+		kinds = append(kinds, e.Kind)
+		if strings.Contains(e.URL, "secret") {
+			t.Errorf("API key was not redacted from %s", e.URL)
+		}
+	}
+	want := []EventKind{EventRequest, EventResponse, EventDecodeError}
+	if fmt.Sprint(kinds) != fmt.Sprint(want) {
+		t.Errorf("Expected events %v, got %v", want, kinds)
+	}
+	if events[1].StatusCode != http.StatusOK || events[1].Attempt != 1 {
+		t.Errorf("Unexpected response event %+v", events[1])
+	}
+}
+
+func TestSubmitRequestLoggerTransportError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.Close()
+
+	var events []LogEvent
+	c := NewClient("secret", WithBaseURL(ts.URL), WithInsecureHTTP())
+	c.RetryPolicy.MaxAttempts = 2
+	c.RetryPolicy.BaseWait = time.Millisecond
+	c.Logger = LoggerFunc(func(ctx context.Context, e LogEvent) {
+		events = append(events, e)
+	})
+
+	_, err := c.GetPet(Options{ID: "42"})
+	if err == nil {
+		t.Fatal("Expected transport error")
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("API key was not redacted from the returned error %v", err)
+	}
+
+	var errored int
+	for _, e := range events {
+		if strings.Contains(e.URL, "secret") || (e.Err != nil && strings.Contains(e.Err.Error(), "secret")) {
+			t.Errorf("API key was not redacted from %+v", e)
+		}
+		if e.Err != nil {
+			errored++
+		}
+	}
+	if errored != 3 {
+		t.Errorf("Expected two response events and a retry event with errors, got %d", errored)
+	}
+}
+
+func TestNewClientOptions(t *testing.T) {
+	var userAgent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+		fmt.Fprint(w, `{"petfinder":{"shelter":{"id":{"$t":"TX1203"}}}}`)
+	}))
+	defer ts.Close()
+
+	if c := NewClient("key"); c.baseURL != "https://api.petfinder.com/" {
+		t.Errorf("Expected HTTPS by default, got %s", c.baseURL)
+	}
+
+	c := NewClient("key", WithBaseURL(ts.URL))
+	if _, err := c.GetShelter(Options{ID: "TX1203"}); err == nil {
+		t.Errorf("Expected plain HTTP to be refused without WithInsecureHTTP")
+	}
+
+	httpClient := &http.Client{}
+	c = NewClient("key",
+		WithBaseURL(ts.URL),
+		WithInsecureHTTP(),
+		WithHTTPClient(httpClient),
+		WithTimeout(time.Second),
+		WithUserAgent("shelter-sync/1.0"),
+	)
+	if _, err := c.GetShelter(Options{ID: "TX1203"}); err != nil {
+		t.Fatal(err)
+	}
+	if userAgent != "shelter-sync/1.0" {
+		t.Errorf("Unexpected user agent %q", userAgent)
+	}
+	if c.HTTPClient.Timeout != time.Second || httpClient.Timeout != 0 {
+		t.Errorf("Expected timeout on a copy of the HTTP client, got %v and %v", c.HTTPClient.Timeout, httpClient.Timeout)
+	}
+
+	c = NewClient("key", WithHTTPClient(nil), WithTimeout(time.Second))
+	if c.HTTPClient == nil || c.HTTPClient.Timeout != time.Second {
+		t.Errorf("Expected a nil HTTP client to keep the default, got %v", c.HTTPClient)
+	}
+}
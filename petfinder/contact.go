@@ -0,0 +1,197 @@
+package petfinder
+
+import (
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+//Address is a postal address as reported by the API
+type Address struct {
+	Address1 string
+	Address2 string
+	City     string
+	State    string
+	Zip      string
+	Country  string // empty for pets, whose shelter's country is not reported
+}
+
+//Contact holds the contact details of a shelter or of the person responsible for a pet
+type Contact struct {
+	Address
+	Phone string
+	Email string
+	Fax   string
+}
+
+//callingCodes maps countries served by Petfinder to their international calling code
+//and the number of digits in a national number
+var callingCodes = map[string]struct {
+	code   string
+	digits int
+}{
+	"US":            {"1", 10},
+	"USA":           {"1", 10},
+	"UNITED STATES": {"1", 10},
+	"CA":            {"1", 10},
+	"CANADA":        {"1", 10},
+	"PR":            {"1", 10},
+	"PUERTO RICO":   {"1", 10},
+	"MX":            {"52", 10},
+	"MEXICO":        {"52", 10},
+}
+
+var (
+	//phoneExtension matches an extension such as "ext. 12", "x12" or "#12", or a bare "ext" label, following the number
+	phoneExtension = regexp.MustCompile(`(?i)(?:[\s,;]*(?:ext\.?|extension|x|#)\s*\d{1,6}|[\s,;]+(?:ext|extension|x|#)\.?)\s*$`)
+	//vanityPhone matches numbers spelled with letters such as 1-800-PET-SAVE: groups of letters
+	//joined to the digits by separators, with nothing after them
+	vanityPhone = regexp.MustCompile(`^\+?[\d\s().-]*\d[-.][A-Za-z][A-Za-z\d]*(?:[-.][A-Za-z\d]+)*$`)
+	//phoneNumber matches the digits and separators of a number, leaving any text after it
+	phoneNumber = regexp.MustCompile(`^\+?[\d\s().-]*`)
+)
+
+//maxPhoneDigits is the most digits an E.164 number may have
+const maxPhoneDigits = 15
+
+//keypad maps letters to the digit of their telephone key
+var keypad = strings.NewReplacer(
+	"A", "2", "B", "2", "C", "2", "D", "3", "E", "3", "F", "3", "G", "4", "H", "4", "I", "4",
+	"J", "5", "K", "5", "L", "5", "M", "6", "N", "6", "O", "6", "P", "7", "Q", "7", "R", "7", "S", "7",
+	"T", "8", "U", "8", "V", "8", "W", "9", "X", "9", "Y", "9", "Z", "9",
+)
+
+//NormalizePhone strips the formatting from a phone number. When the country is known
+//and the number is complete it is returned in E.164 form, e.g. +19725550123,
+//otherwise only its digits are kept. Labels before the number such as "Tel:",
+//extensions and any other text after it are dropped, and vanity numbers are
+//converted to digits.
+func NormalizePhone(phone, country string) string {
+	phone = strings.TrimSpace(phone)
+	if loc := phoneExtension.FindStringIndex(phone); loc != nil && strings.ContainsAny(phone[:loc[0]], "0123456789") {
+		phone = phone[:loc[0]]
+	}
+	if i := strings.IndexAny(phone, "+(0123456789"); i >= 0 {
+		phone = phone[i:]
+	}
+	if vanityPhone.MatchString(phone) {
+		phone = keypad.Replace(strings.ToUpper(phone))
+	} else {
+		phone = phoneNumber.FindString(phone)
+	}
+
+	international := strings.HasPrefix(phone, "+")
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+	if digits == "" {
+		return ""
+	}
+	if international {
+		return "+" + digits
+	}
+
+	cc, ok := callingCodes[strings.ToUpper(strings.TrimSpace(country))]
+	if !ok {
+		return digits
+	}
+	switch len(digits) {
+	case cc.digits:
+		return "+" + cc.code + digits
+	case len(cc.code) + cc.digits:
+		if strings.HasPrefix(digits, cc.code) {
+			return "+" + digits
+		}
+	}
+	return digits
+}
+
+//NormalizeEmail trims and lowercases an email address
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+//Normalized returns a copy of the contact with phone numbers and email normalized
+//and surrounding whitespace removed from every field
+func (c Contact) Normalized() Contact {
+	n := Contact{
+		Address: Address{
+			Address1: strings.TrimSpace(c.Address1),
+			Address2: strings.TrimSpace(c.Address2),
+			City:     strings.TrimSpace(c.City),
+			State:    strings.TrimSpace(c.State),
+			Zip:      strings.TrimSpace(c.Zip),
+			Country:  strings.TrimSpace(c.Country),
+		},
+		Email: NormalizeEmail(c.Email),
+	}
+	n.Phone = NormalizePhone(c.Phone, n.Country)
+	n.Fax = NormalizePhone(c.Fax, n.Country)
+	return n
+}
+
+//HasValidEmail reports whether the email is a single well formed address with a dotted domain
+func (c Contact) HasValidEmail() bool {
+	email := strings.TrimSpace(c.Email)
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return false
+	}
+	at := strings.LastIndex(email, "@")
+	domain := email[at+1:]
+	return strings.Contains(domain, ".") && !strings.HasSuffix(domain, ".")
+}
+
+//HasValidPhone reports whether the phone number is complete: numbers for countries
+//with a known calling code must have exactly their national length, and other
+//numbers must fit in E.164
+func (c Contact) HasValidPhone() bool {
+	phone := NormalizePhone(c.Phone, c.Country)
+	digits := strings.TrimPrefix(phone, "+")
+	if digits == phone {
+		// complete numbers of a known country are always normalized to E.164
+		if _, ok := callingCodes[strings.ToUpper(strings.TrimSpace(c.Country))]; ok {
+			return false
+		}
+	} else {
+		for _, cc := range callingCodes {
+			if strings.HasPrefix(digits, cc.code) {
+				return len(digits) == len(cc.code)+cc.digits
+			}
+		}
+	}
+	return len(digits) >= 10 && len(digits) <= maxPhoneDigits
+}
+
+//Mailing formats the address for a mailing label, one line per part:
+//street lines, then "City, State Zip", then the country when known.
+//Empty parts are left out.
+func (a Address) Mailing() string {
+	var lines []string
+	for _, line := range []string{a.Address1, a.Address2} {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	locality := strings.TrimSpace(a.City)
+	region := strings.TrimSpace(strings.TrimSpace(a.State) + " " + strings.TrimSpace(a.Zip))
+	switch {
+	case locality != "" && region != "":
+		locality += ", " + region
+	case region != "":
+		locality = region
+	}
+	if locality != "" {
+		lines = append(lines, locality)
+	}
+
+	if country := strings.TrimSpace(a.Country); country != "" {
+		lines = append(lines, country)
+	}
+	return strings.Join(lines, "\n")
+}
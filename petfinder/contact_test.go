@@ -0,0 +1,124 @@
+package petfinder
+
+import (
+	"testing"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone, country, want string
+	}{
+		{"(972) 555-0134", "US", "+19725550134"},
+		{"1-972-555-0134", "us", "+19725550134"},
+		{"972.555.0134 ext. 12", "Canada", "+19725550134"},
+		{"(972) 555-0134", "", "9725550134"},
+		{"+44 20 7946 0958", "US", "+442079460958"},
+		{"555-0134", "US", "5550134"},
+		{"55 1234 5678", "MX", "+525512345678"},
+		{"", "US", ""},
+		{"Tel: 972-555-0134", "US", "+19725550134"},
+		{"Phone (972) 555-0134", "", "9725550134"},
+		{"972-555-0134 x12", "US", "+19725550134"},
+		{"972-555-0134, ext 1234", "US", "+19725550134"},
+		{"972-555-0134 #5", "US", "+19725550134"},
+		{"972-555-0134 extension 5", "US", "+19725550134"},
+		{"1-800-PET-SAVE", "US", "+18007387283"},
+		{"1-800-pet-save ext. 7", "US", "+18007387283"},
+		{"(972) 555-0134 (cell)", "US", "+19725550134"},
+		{"972-555-0134 ask for Sue", "US", "+19725550134"},
+		{"972-555-0134 Main Office", "US", "+19725550134"},
+		{"(972) 555-0134 Ext", "US", "+19725550134"},
+		{"972-555-0134 X", "US", "+19725550134"},
+		{"972-555-0134 ask for Sue", "", "9725550134"},
+		{"1-800-PET-SAVE x", "US", "+18007387283"},
+	}
+	for _, tt := range tests {
+		if got := NormalizePhone(tt.phone, tt.country); got != tt.want {
+			t.Errorf("NormalizePhone(%q, %q) = %q, expected %q", tt.phone, tt.country, got, tt.want)
+		}
+	}
+}
+
+func TestContactHasValidEmail(t *testing.T) {
+	tests := map[string]bool{
+		"adopt@northtexasrescue.org":   true,
+		" Adopt@NorthTexasRescue.org ": true,
+		"":                             false,
+		"adopt":                        false,
+		"adopt@localhost":              false,
+		"Rescue <adopt@rescue.org>":    false,
+		"a@b.org, c@d.org":             false,
+	}
+	for email, want := range tests {
+		if got := (Contact{Email: email}).HasValidEmail(); got != want {
+			t.Errorf("HasValidEmail(%q) = %v, expected %v", email, got, want)
+		}
+	}
+}
+
+func TestContactHasValidPhone(t *testing.T) {
+	tests := []struct {
+		phone, country string
+		want           bool
+	}{
+		{"(972) 555-0134", "US", true},
+		{"972-555-0134 ask for Sue", "US", true},
+		{"1-800-PET-SAVE", "US", true},
+		{"555-0134", "US", false},
+		{"972-555-01345", "US", false},
+		{"+1 972 555 01345", "", false},
+		{"+44 20 7946 0958", "US", true},
+		{"(972) 555-0134", "", true},
+		{"9725550134275367783", "", false},
+		{"", "US", false},
+	}
+	for _, tt := range tests {
+		if got := (Contact{Address: Address{Country: tt.country}, Phone: tt.phone}).HasValidPhone(); got != tt.want {
+			t.Errorf("HasValidPhone(%q, %q) = %v, expected %v", tt.phone, tt.country, got, tt.want)
+		}
+	}
+}
+
+func TestAddressMailing(t *testing.T) {
+	tests := []struct {
+		address Address
+		want    string
+	}{
+		{
+			Address{Address1: "500 Main St", Address2: "Suite 100", City: "McKinney", State: "TX", Zip: "75069", Country: "US"},
+			"500 Main St\nSuite 100\nMcKinney, TX 75069\nUS",
+		},
+		{Address{City: "Plano", State: "TX"}, "Plano, TX"},
+		{Address{State: "TX", Zip: "75069"}, "TX 75069"},
+		{Address{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.address.Mailing(); got != tt.want {
+			t.Errorf("Mailing() = %q, expected %q", got, tt.want)
+		}
+	}
+}
+
+func TestFixtureContact(t *testing.T) {
+	c, _ := newFixtureClient(t)
+	pet, err := c.GetPet(Options{ID: "39930101"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	shelter, err := c.GetShelter(Options{ID: "TX1203"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	petContact := pet.Contact.Normalized()
+	shelterContact := shelter.Contact.Normalized()
+	if petContact.Email != shelterContact.Email || !petContact.HasValidEmail() {
+		t.Errorf("Expected matching normalized emails, got %q and %q", petContact.Email, shelterContact.Email)
+	}
+	if petContact.Phone != "9725550134" || shelterContact.Phone != "+19725550134" {
+		t.Errorf("Unexpected normalized phones %q and %q", petContact.Phone, shelterContact.Phone)
+	}
+	if shelter.City != shelter.Contact.City {
+		t.Errorf("Expected contact fields promoted onto the shelter")
+	}
+}
@@ -0,0 +1,241 @@
+package petfinder
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//Description is a pet description cleaned of markup and encoding damage and split into paragraphs
+type Description struct {
+	Paragraphs  []string // body of the description
+	Boilerplate []string // trailing paragraphs recognised as shelter boilerplate, e.g. how to apply
+}
+
+var (
+	scriptTags    = regexp.MustCompile(`(?is)<(script|style)\b.*?</(script|style)\s*>`)
+	paragraphTags = regexp.MustCompile(`(?i)</?(p|div|ul|ol|h[1-6])\b[^>]*>`)
+	lineTags      = regexp.MustCompile(`(?i)<(br|li)\b[^>]*>`)
+	anyTag        = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	spaces        = regexp.MustCompile(`[ \t\f\v\x{00a0}]+`)
+)
+
+//boilerplate matches the opening of paragraphs shelters append to every description.
+//Patterns are anchored to the start of the paragraph so text about the pet itself,
+//such as "He is neutered and up to date on shots", is never mistaken for a footer.
+var boilerplate = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^(if you are )?(interested in adopting|to adopt)\b`),
+	regexp.MustCompile(`(?i)^(please )?(fill out|complete|submit) (an|our|the) (online )?(adoption )?application\b`),
+	regexp.MustCompile(`(?i)^(our |the )?adoption fees?\b`),
+	regexp.MustCompile(`(?i)^(please )?(visit|see|check out) our (web ?site|facebook|page)\b`),
+	regexp.MustCompile(`(?i)^for more info(rmation)?\b`),
+	regexp.MustCompile(`(?i)^all (of )?our (dogs|cats|pets|animals) (are|come)\b`),
+	regexp.MustCompile(`(?i)^(please )?(call|email|contact) (us|the shelter|the rescue)\b`),
+}
+
+//ParseDescription cleans a raw description from the API. It repairs UTF-8 that was
+//double encoded as Windows-1252, decodes HTML entities including doubly escaped ones,
+//turns block tags into paragraph breaks and <br> into line breaks, drops scripts
+//and any other markup, including markup that was itself escaped, and collapses
+//whitespace. Trailing paragraphs matching common boilerplate are moved to
+//Boilerplate as long as at least one paragraph remains.
+func ParseDescription(raw string) Description {
+	s := fixMojibake(raw)
+	// markup escaped once or more, e.g. &lt;br&gt;, only becomes a tag once unescaped
+	s = stripTags(s)
+	for i := 0; i < 3; i++ {
+		unescaped := html.UnescapeString(s)
+		if unescaped == s {
+			break
+		}
+		s = stripTags(unescaped)
+	}
+	s = strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(s)
+
+	var d Description
+	var lines []string
+	flush := func() {
+		if len(lines) > 0 {
+			d.Paragraphs = append(d.Paragraphs, strings.Join(lines, "\n"))
+			lines = nil
+		}
+	}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(spaces.ReplaceAllString(line, " "))
+		if line == "" {
+			flush()
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+
+	for len(d.Paragraphs) > 1 && isBoilerplate(d.Paragraphs[len(d.Paragraphs)-1]) {
+		last := len(d.Paragraphs) - 1
+		d.Boilerplate = append([]string{d.Paragraphs[last]}, d.Boilerplate...)
+		d.Paragraphs = d.Paragraphs[:last]
+	}
+	return d
+}
+
+//stripTags replaces block and line tags with breaks and drops scripts and any other markup
+func stripTags(s string) string {
+	s = scriptTags.ReplaceAllString(s, "")
+	s = paragraphTags.ReplaceAllString(s, "\n\n")
+	s = lineTags.ReplaceAllString(s, "\n")
+	return anyTag.ReplaceAllString(s, "")
+}
+
+func isBoilerplate(paragraph string) bool {
+	for _, re := range boilerplate {
+		if re.MatchString(paragraph) {
+			return true
+		}
+	}
+	return false
+}
+
+//Text returns the body of the description as plain text with paragraphs separated by a blank line
+func (d Description) Text() string {
+	return strings.Join(d.Paragraphs, "\n\n")
+}
+
+//HTML returns the body of the description as escaped HTML paragraphs, safe to embed in a page
+func (d Description) HTML() string {
+	var b strings.Builder
+	for _, p := range d.Paragraphs {
+		lines := strings.Split(p, "\n")
+		for i := range lines {
+			lines[i] = html.EscapeString(lines[i])
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.Join(lines, "<br>"))
+		b.WriteString("</p>")
+	}
+	return b.String()
+}
+
+//Summary returns the body of the description on a single line, shortened to at most
+//maxLen characters by cutting at a word boundary and appending "...".
+//The ASCII ellipsis keeps the summary within the SMS character set.
+//A maxLen of 0 or less returns an empty summary.
+func (d Description) Summary(maxLen int) string {
+	if maxLen <= 0 {
+		return ""
+	}
+	s := strings.Join(strings.Fields(strings.Join(d.Paragraphs, " ")), " ")
+	if utf8.RuneCountInString(s) <= maxLen {
+		return s
+	}
+
+	const ellipsis = "..."
+	if maxLen <= len(ellipsis) {
+		return ellipsis[:maxLen]
+	}
+	runes := []rune(s)
+	cut := runes[:maxLen-len(ellipsis)]
+	if i := lastSpace(cut); i > 0 && !unicode.IsSpace(runes[len(cut)]) {
+		cut = cut[:i]
+	}
+	return strings.TrimRightFunc(string(cut), func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(",;:-", r)
+	}) + ellipsis
+}
+
+func lastSpace(runes []rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if unicode.IsSpace(runes[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+//HasBoilerplate reports whether any trailing boilerplate was found
+func (d Description) HasBoilerplate() bool {
+	return len(d.Boilerplate) > 0
+}
+
+//CleanDescription parses the pet's raw description
+func (p Pet) CleanDescription() Description {
+	return ParseDescription(p.Description)
+}
+
+//DescriptionText returns the pet's description as plain text without markup or boilerplate
+func (p Pet) DescriptionText() string {
+	return p.CleanDescription().Text()
+}
+
+//DescriptionHTML returns the pet's description as safe HTML without boilerplate
+func (p Pet) DescriptionHTML() string {
+	return p.CleanDescription().HTML()
+}
+
+//DescriptionSummary returns the pet's description on one line, shortened to at most maxLen characters
+func (p Pet) DescriptionSummary(maxLen int) string {
+	return p.CleanDescription().Summary(maxLen)
+}
+
+//cp1252 maps the Windows-1252 characters in 0x80-0x9f back to their byte
+var cp1252 = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+//mojibakeByte returns the byte a character decodes from under Windows-1252 or Latin-1
+func mojibakeByte(r rune) (byte, bool) {
+	if b, ok := cp1252[r]; ok {
+		return b, true
+	}
+	if r < 0x100 {
+		return byte(r), true
+	}
+	return 0, false
+}
+
+//fixMojibake repairs UTF-8 text that was decoded as Windows-1252 and encoded again,
+//e.g. "itâ€™s" back to "it’s". Only character sequences that form a valid multibyte
+//UTF-8 sequence are replaced, so genuine accented text is left alone.
+func fixMojibake(s string) string {
+	if !strings.ContainsAny(s, "ÂÃâ") {
+		return s
+	}
+
+	runes := []rune(s)
+	var b strings.Builder
+	for i := 0; i < len(runes); i++ {
+		lead, ok := mojibakeByte(runes[i])
+		n := 0
+		switch {
+		case ok && lead >= 0xc2 && lead <= 0xdf:
+			n = 2
+		case ok && lead >= 0xe0 && lead <= 0xef:
+			n = 3
+		case ok && lead >= 0xf0 && lead <= 0xf4:
+			n = 4
+		}
+		if n > 0 && i+n <= len(runes) {
+			buf := []byte{lead}
+			for _, r := range runes[i+1 : i+n] {
+				c, ok := mojibakeByte(r)
+				if !ok || c < 0x80 || c > 0xbf {
+					break
+				}
+				buf = append(buf, c)
+			}
+			if len(buf) == n {
+				if r, size := utf8.DecodeRune(buf); r != utf8.RuneError && size == n {
+					b.WriteRune(r)
+					i += n - 1
+					continue
+				}
+			}
+		}
+		b.WriteRune(runes[i])
+	}
+	return b.String()
+}
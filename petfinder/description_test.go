@@ -0,0 +1,119 @@
+package petfinder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDescription(t *testing.T) {
+	raw := "<p>Max is a sweet boy &amp;amp; loves kids. He&amp;#39;s   house trained.</p>" +
+		"<p>He itâ€™s a cafÃ© regular<br/>and a café regular.</p>" +
+		"<script>x</script>I &lt;3 walks&#13;\n\n" +
+		"To adopt Max, please fill out an application at our website."
+	d := ParseDescription(raw)
+
+	want := []string{
+		"Max is a sweet boy & loves kids. He's house trained.",
+		"He it’s a café regular\nand a café regular.",
+		"I <3 walks",
+	}
+	if !reflect.DeepEqual(d.Paragraphs, want) {
+		t.Errorf("Unexpected paragraphs %q", d.Paragraphs)
+	}
+	if !d.HasBoilerplate() || d.Boilerplate[0] != "To adopt Max, please fill out an application at our website." {
+		t.Errorf("Unexpected boilerplate %q", d.Boilerplate)
+	}
+
+	wantHTML := "<p>Max is a sweet boy &amp; loves kids. He&#39;s house trained.</p>" +
+		"<p>He it’s a café regular<br>and a café regular.</p><p>I &lt;3 walks</p>"
+	if got := d.HTML(); got != wantHTML {
+		t.Errorf("Unexpected HTML %q", got)
+	}
+}
+
+func TestParseDescriptionEscapedMarkup(t *testing.T) {
+	d := ParseDescription("Max is a sweet boy.&lt;br&gt;He loves walks.&amp;lt;p&amp;gt;&lt;b&gt;Housetrained&lt;/b&gt; &amp;lt;3")
+	want := []string{"Max is a sweet boy.\nHe loves walks.", "Housetrained <3"}
+	if !reflect.DeepEqual(d.Paragraphs, want) {
+		t.Errorf("Unexpected paragraphs %q", d.Paragraphs)
+	}
+}
+
+func TestParseDescriptionLiteralBrackets(t *testing.T) {
+	tests := map[string]string{
+		"a < b > c":                   "a < b > c",
+		"a &lt; b &gt; c":             "a < b > c",
+		"&amp;amp;lt;b&amp;amp;gt;hi": "hi",
+	}
+	for raw, want := range tests {
+		if got := ParseDescription(raw).Text(); got != want {
+			t.Errorf("ParseDescription(%q) = %q, expected %q", raw, got, want)
+		}
+	}
+}
+
+func TestParseDescriptionBoilerplate(t *testing.T) {
+	tests := []struct {
+		footer      string
+		boilerplate bool
+	}{
+		{"He is neutered and up to date on shots, and loves kids.", false},
+		{"He came to us with an application of flea treatment.", false},
+		{"Please call him by name and he comes running.", false},
+		{"Interested in adopting? Visit our website.", true},
+		{"Please fill out an adoption application at rescue.org.", true},
+		{"Adoption fee is $150.", true},
+		{"All of our dogs are spayed, neutered and microchipped.", true},
+		{"Please contact us to meet him.", true},
+	}
+	for _, tt := range tests {
+		d := ParseDescription("Max is a sweet boy.\n\n" + tt.footer)
+		if d.HasBoilerplate() != tt.boilerplate {
+			t.Errorf("%q: expected boilerplate %v, got %+v", tt.footer, tt.boilerplate, d)
+		}
+	}
+}
+
+func TestParseDescriptionKeepsOnlyParagraph(t *testing.T) {
+	d := ParseDescription("Please call for more information.")
+	if len(d.Paragraphs) != 1 || d.HasBoilerplate() {
+		t.Errorf("Expected a lone paragraph to be kept, got %+v", d)
+	}
+}
+
+func TestDescriptionSummary(t *testing.T) {
+	d := Description{Paragraphs: []string{"Biscuit is a happy dog,", "who loves kids."}}
+	tests := []struct {
+		maxLen int
+		want   string
+	}{
+		{100, "Biscuit is a happy dog, who loves kids."},
+		{26, "Biscuit is a happy dog..."},
+		{27, "Biscuit is a happy dog..."},
+		{12, "Biscuit..."},
+		{8, "Biscu..."},
+		{2, ".."},
+		{0, ""},
+		{-1, ""},
+	}
+	for _, tt := range tests {
+		if got := d.Summary(tt.maxLen); got != tt.want {
+			t.Errorf("Summary(%d) = %q, expected %q", tt.maxLen, got, tt.want)
+		}
+	}
+}
+
+func TestFixtureDescription(t *testing.T) {
+	c, _ := newFixtureClient(t)
+	pet, err := c.GetPet(Options{ID: "39930101"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := pet.DescriptionText(); got != "Biscuit is a happy 3 year old lab mix who loves kids & long walks." {
+		t.Errorf("Unexpected description text %q", got)
+	}
+	if !pet.CleanDescription().HasBoilerplate() {
+		t.Errorf("Expected the application footer to be detected")
+	}
+}
@@ -0,0 +1,392 @@
+package petfinder
+
+import (
+	"fmt"
+	"strings"
+)
+
+//enumEntry is an API value of an enumerated type along with its human-readable label
+type enumEntry struct {
+	value string
+	label string
+}
+
+//lookupEnum matches s case-insensitively against the values and labels of an enumerated type
+func lookupEnum(entries []enumEntry, s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	for _, e := range entries {
+		if strings.EqualFold(s, e.value) || strings.EqualFold(s, e.label) {
+			return e.value, true
+		}
+	}
+	return "", false
+}
+
+//normalizeEnum maps a value returned by the API onto a known constant.
+//Values the package does not know about are kept verbatim rather than failing the decode.
+func normalizeEnum(entries []enumEntry, s string) string {
+	if v, ok := lookupEnum(entries, s); ok {
+		return v
+	}
+	return s
+}
+
+func enumLabel(entries []enumEntry, v string) string {
+	for _, e := range entries {
+		if e.value == v {
+			return e.label
+		}
+	}
+	return v
+}
+
+func enumValid(entries []enumEntry, v string) bool {
+	for _, e := range entries {
+		if e.value == v {
+			return true
+		}
+	}
+	return false
+}
+
+//Animal is a type of animal accepted and returned by the API
+type Animal string
+
+//Animal values
+const (
+	AnimalBarnyard   Animal = "barnyard"
+	AnimalBird       Animal = "bird"
+	AnimalCat        Animal = "cat"
+	AnimalDog        Animal = "dog"
+	AnimalHorse      Animal = "horse"
+	AnimalReptile    Animal = "reptile"
+	AnimalSmallFurry Animal = "smallfurry"
+)
+
+var animalEntries = []enumEntry{
+	{string(AnimalBarnyard), "Barnyard"},
+	{string(AnimalBird), "Bird"},
+	{string(AnimalCat), "Cat"},
+	{string(AnimalDog), "Dog"},
+	{string(AnimalHorse), "Horse"},
+	{string(AnimalReptile), "Reptile"},
+	{string(AnimalSmallFurry), "Small & Furry"},
+}
+
+//ParseAnimal parses an animal from its API value or label, ignoring case
+func ParseAnimal(s string) (Animal, error) {
+	v, ok := lookupEnum(animalEntries, s)
+	if !ok {
+		return "", fmt.Errorf("Invalid animal %q", s)
+	}
+	return Animal(v), nil
+}
+
+//String returns the API value
+func (v Animal) String() string {
+	return string(v)
+}
+
+//Label returns the human-readable name of the value
+func (v Animal) Label() string {
+	return enumLabel(animalEntries, string(v))
+}
+
+//Valid reports whether the value is one the API accepts
+func (v Animal) Valid() bool {
+	return enumValid(animalEntries, string(v))
+}
+
+//MarshalText implements encoding.TextMarshaler
+func (v Animal) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler, accepting anything ParseAnimal does
+func (v *Animal) UnmarshalText(text []byte) error {
+	parsed, err := ParseAnimal(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+//Size is the size of an animal
+type Size string
+
+//Size values
+const (
+	SizeSmall      Size = "S"
+	SizeMedium     Size = "M"
+	SizeLarge      Size = "L"
+	SizeExtraLarge Size = "XL"
+)
+
+var sizeEntries = []enumEntry{
+	{string(SizeSmall), "Small"},
+	{string(SizeMedium), "Medium"},
+	{string(SizeLarge), "Large"},
+	{string(SizeExtraLarge), "Extra Large"},
+}
+
+//ParseSize parses a size from its API value or label, ignoring case
+func ParseSize(s string) (Size, error) {
+	v, ok := lookupEnum(sizeEntries, s)
+	if !ok {
+		return "", fmt.Errorf("Invalid size %q", s)
+	}
+	return Size(v), nil
+}
+
+//String returns the API value
+func (v Size) String() string {
+	return string(v)
+}
+
+//Label returns the human-readable name of the value
+func (v Size) Label() string {
+	return enumLabel(sizeEntries, string(v))
+}
+
+//Valid reports whether the value is one the API accepts
+func (v Size) Valid() bool {
+	return enumValid(sizeEntries, string(v))
+}
+
+//MarshalText implements encoding.TextMarshaler
+func (v Size) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler, accepting anything ParseSize does
+func (v *Size) UnmarshalText(text []byte) error {
+	parsed, err := ParseSize(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+//Sex is the sex of an animal
+type Sex string
+
+//Sex values
+const (
+	SexMale   Sex = "M"
+	SexFemale Sex = "F"
+)
+
+var sexEntries = []enumEntry{
+	{string(SexMale), "Male"},
+	{string(SexFemale), "Female"},
+}
+
+//ParseSex parses a sex from its API value or label, ignoring case
+func ParseSex(s string) (Sex, error) {
+	v, ok := lookupEnum(sexEntries, s)
+	if !ok {
+		return "", fmt.Errorf("Invalid sex %q", s)
+	}
+	return Sex(v), nil
+}
+
+//String returns the API value
+func (v Sex) String() string {
+	return string(v)
+}
+
+//Label returns the human-readable name of the value
+func (v Sex) Label() string {
+	return enumLabel(sexEntries, string(v))
+}
+
+//Valid reports whether the value is one the API accepts
+func (v Sex) Valid() bool {
+	return enumValid(sexEntries, string(v))
+}
+
+//MarshalText implements encoding.TextMarshaler
+func (v Sex) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler, accepting anything ParseSex does
+func (v *Sex) UnmarshalText(text []byte) error {
+	parsed, err := ParseSex(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+//Age is the age group of an animal
+type Age string
+
+//Age values
+const (
+	AgeBaby   Age = "Baby"
+	AgeYoung  Age = "Young"
+	AgeAdult  Age = "Adult"
+	AgeSenior Age = "Senior"
+)
+
+var ageEntries = []enumEntry{
+	{string(AgeBaby), "Baby"},
+	{string(AgeYoung), "Young"},
+	{string(AgeAdult), "Adult"},
+	{string(AgeSenior), "Senior"},
+}
+
+//ParseAge parses an age from its API value or label, ignoring case
+func ParseAge(s string) (Age, error) {
+	v, ok := lookupEnum(ageEntries, s)
+	if !ok {
+		return "", fmt.Errorf("Invalid age %q", s)
+	}
+	return Age(v), nil
+}
+
+//String returns the API value
+func (v Age) String() string {
+	return string(v)
+}
+
+//Label returns the human-readable name of the value
+func (v Age) Label() string {
+	return enumLabel(ageEntries, string(v))
+}
+
+//Valid reports whether the value is one the API accepts
+func (v Age) Valid() bool {
+	return enumValid(ageEntries, string(v))
+}
+
+//MarshalText implements encoding.TextMarshaler
+func (v Age) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler, accepting anything ParseAge does
+func (v *Age) UnmarshalText(text []byte) error {
+	parsed, err := ParseAge(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+//OutputLevel is how much of a pet record the API returns
+type OutputLevel string
+
+//OutputLevel values
+const (
+	OutputBasic OutputLevel = "basic"
+	OutputFull  OutputLevel = "full"
+	OutputID    OutputLevel = "id"
+)
+
+var outputLevelEntries = []enumEntry{
+	{string(OutputBasic), "Basic"},
+	{string(OutputFull), "Full"},
+	{string(OutputID), "ID"},
+}
+
+//ParseOutputLevel parses an output from its API value or label, ignoring case
+func ParseOutputLevel(s string) (OutputLevel, error) {
+	v, ok := lookupEnum(outputLevelEntries, s)
+	if !ok {
+		return "", fmt.Errorf("Invalid output %q", s)
+	}
+	return OutputLevel(v), nil
+}
+
+//String returns the API value
+func (v OutputLevel) String() string {
+	return string(v)
+}
+
+//Label returns the human-readable name of the value
+func (v OutputLevel) Label() string {
+	return enumLabel(outputLevelEntries, string(v))
+}
+
+//Valid reports whether the value is one the API accepts
+func (v OutputLevel) Valid() bool {
+	return enumValid(outputLevelEntries, string(v))
+}
+
+//MarshalText implements encoding.TextMarshaler
+func (v OutputLevel) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler, accepting anything ParseOutputLevel does
+func (v *OutputLevel) UnmarshalText(text []byte) error {
+	parsed, err := ParseOutputLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+//PetStatus is the adoption status of a pet
+type PetStatus string
+
+//PetStatus values
+const (
+	PetStatusAdoptable PetStatus = "A"
+	PetStatusHold      PetStatus = "H"
+	PetStatusPending   PetStatus = "P"
+	PetStatusAdopted   PetStatus = "X"
+)
+
+var petStatusEntries = []enumEntry{
+	{string(PetStatusAdoptable), "Adoptable"},
+	{string(PetStatusHold), "Hold"},
+	{string(PetStatusPending), "Pending"},
+	{string(PetStatusAdopted), "Adopted/Removed"},
+}
+
+//ParsePetStatus parses a pet status from its API value or label, ignoring case
+func ParsePetStatus(s string) (PetStatus, error) {
+	v, ok := lookupEnum(petStatusEntries, s)
+	if !ok {
+		return "", fmt.Errorf("Invalid status %q", s)
+	}
+	return PetStatus(v), nil
+}
+
+//String returns the API value
+func (v PetStatus) String() string {
+	return string(v)
+}
+
+//Label returns the human-readable name of the value
+func (v PetStatus) Label() string {
+	return enumLabel(petStatusEntries, string(v))
+}
+
+//Valid reports whether the value is one the API accepts
+func (v PetStatus) Valid() bool {
+	return enumValid(petStatusEntries, string(v))
+}
+
+//MarshalText implements encoding.TextMarshaler
+func (v PetStatus) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler, accepting anything ParsePetStatus does
+func (v *PetStatus) UnmarshalText(text []byte) error {
+	parsed, err := ParsePetStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
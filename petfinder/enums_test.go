@@ -0,0 +1,50 @@
+package petfinder
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseEnums(t *testing.T) {
+	if a, err := ParseAnimal("Small & Furry"); err != nil || a != AnimalSmallFurry {
+		t.Errorf("ParseAnimal by label = %q, %v", a, err)
+	}
+	if s, err := ParseSize("xl"); err != nil || s != SizeExtraLarge {
+		t.Errorf("ParseSize ignoring case = %q, %v", s, err)
+	}
+	if _, err := ParseSex("X"); err == nil {
+		t.Errorf("Expected invalid sex to fail")
+	}
+	if PetStatusAdoptable.Label() != "Adoptable" || SexFemale.Label() != "Female" {
+		t.Errorf("Unexpected labels %q %q", PetStatusAdoptable.Label(), SexFemale.Label())
+	}
+	if Animal("rabbit").Valid() || !AgeSenior.Valid() {
+		t.Errorf("Unexpected validity")
+	}
+}
+
+func TestEnumJSON(t *testing.T) {
+	var v struct {
+		Animal Animal
+		Status PetStatus
+		Output OutputLevel
+	}
+	if err := json.Unmarshal([]byte(`{"Animal":"Dog","Status":"adoptable","Output":"FULL"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Animal != AnimalDog || v.Status != PetStatusAdoptable || v.Output != OutputFull {
+		t.Errorf("Unexpected decoded values %+v", v)
+	}
+
+	buf, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != `{"Animal":"dog","Status":"A","Output":"full"}` {
+		t.Errorf("Unexpected encoding %s", buf)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Animal":"dragon"}`), &v); err == nil {
+		t.Errorf("Expected invalid animal to fail")
+	}
+}
@@ -0,0 +1,111 @@
+package petfinder
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+//Petfinder API status codes reported in the response header
+const (
+	StatusOK           = 100
+	StatusInvalid      = 200
+	StatusNotFound     = 201
+	StatusLimit        = 202
+	StatusLocation     = 203
+	StatusUnauthorized = 300
+	StatusAuthFail     = 301
+	StatusInternal     = 999
+)
+
+//Sentinel errors that an APIError can be matched against with errors.Is
+var (
+	ErrNotFound        = errors.New("petfinder: record not found")
+	ErrUnauthorized    = errors.New("petfinder: unauthorized")
+	ErrRateLimited     = errors.New("petfinder: rate limit exceeded")
+	ErrInvalidArgument = errors.New("petfinder: invalid argument")
+)
+
+//APIError is returned when the Petfinder API reports a non-OK status in the response header
+type APIError struct {
+	Code    int
+	Message string
+	Method  string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("petfinder: %s returned status %d", e.Method, e.Code)
+	}
+	return fmt.Sprintf("petfinder: %s returned status %d: %s", e.Method, e.Code, e.Message)
+}
+
+//Is reports whether the API status code corresponds to one of the sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Code == StatusNotFound
+	case ErrUnauthorized:
+		return e.Code == StatusUnauthorized || e.Code == StatusAuthFail
+	case ErrRateLimited:
+		return e.Code == StatusLimit
+	case ErrInvalidArgument:
+		return e.Code == StatusInvalid || e.Code == StatusLocation
+	}
+	return false
+}
+
+//HTTPError is returned when the API responds with a non-2xx HTTP status
+type HTTPError struct {
+	StatusCode int
+	Method     string
+	RetryAfter time.Duration // delay requested by the Retry-After header, if any
+	Detail     string        // explanation given in the response body, if any
+}
+
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("petfinder: %s returned HTTP status %d %s", e.Method, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+//Is reports whether the HTTP status corresponds to one of the sentinel errors
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrInvalidArgument:
+		return e.StatusCode == http.StatusBadRequest
+	}
+	return false
+}
+
+type headerResponse struct {
+	Petfinder struct {
+		Header header `json:"header"`
+	} `json:"petfinder"`
+}
+
+//err returns an APIError if the header reports anything other than an OK status.
+//A missing status code is treated as OK.
+func (h header) err(apiMethod string) error {
+	if h.Status.Code.T == "" {
+		return nil
+	}
+	code, err := strconv.Atoi(h.Status.Code.T)
+	if err != nil {
+		return fmt.Errorf("petfinder: %s returned malformed status code %q", apiMethod, h.Status.Code.T)
+	}
+	if code == StatusOK {
+		return nil
+	}
+	return &APIError{Code: code, Message: h.Status.Message.T, Method: apiMethod}
+}
@@ -0,0 +1,41 @@
+package petfinder
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestHeaderErr(t *testing.T) {
+	tests := []struct {
+		body     string
+		sentinel error
+	}{
+		{`{"petfinder":{"header":{"status":{"code":{"$t":"100"}}}}}`, nil},
+		{`{"petfinder":{"header":{"status":{"code":{"$t":"201"},"message":{"$t":"shelter opt-out"}}}}}`, ErrNotFound},
+		{`{"petfinder":{"header":{"status":{"code":{"$t":"300"},"message":{"$t":"unauthorized key"}}}}}`, ErrUnauthorized},
+		{`{"petfinder":{"header":{"status":{"code":{"$t":"202"}}}}}`, ErrRateLimited},
+		{`{"petfinder":{"header":{"status":{"code":{"$t":"203"}}}}}`, ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		var resp headerResponse
+		if err := json.Unmarshal([]byte(tt.body), &resp); err != nil {
+			t.Fatal(err)
+		}
+		err := resp.Petfinder.Header.err("pet.get")
+		if tt.sentinel == nil {
+			if err != nil {
+				t.Errorf("Expected no error for %s, got %v", tt.body, err)
+			}
+			continue
+		}
+		if !errors.Is(err, tt.sentinel) {
+			t.Errorf("Expected %v to match %v", err, tt.sentinel)
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.Method != "pet.get" {
+			t.Errorf("Expected APIError for pet.get, got %#v", err)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+//Package export flattens pets and shelters into records with a stable schema
+//and streams them as CSV or newline delimited JSON for spreadsheets and data lakes.
+//
+//Every schema column has a Kind fixing its type in both formats:
+//
+//	Kind    CSV                         NDJSON
+//	String  text                        string
+//	Int     decimal                     number
+//	Float   decimal, empty if unknown   number or null
+//	Bool    true or false               boolean
+//	Time    RFC 3339 in UTC, empty      string or null
+//	        if unknown
+//	List    values joined by "|"        array of strings
+//
+//In CSV, list values containing "|" or "\" have them escaped with a preceding "\",
+//so a list can be recovered with SplitList. An empty list is an empty field, which is
+//also how a list of a single empty value encodes, so that list reads back as empty.
+//Lists of two or more values, empty or not, always round trip.
+//
+//Columns are only ever added to the end of a schema, so readers relying on
+//positions keep working when the package is upgraded.
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aouyang1/go-petfinder/petfinder"
+)
+
+//Kind is the type of a column
+type Kind int
+
+//Kind values
+const (
+	String Kind = iota
+	Int
+	Float
+	Bool
+	Time
+	List
+)
+
+var kindNames = []string{"string", "int", "float", "bool", "time", "list"}
+
+//String returns the lowercase name of the kind
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindNames[k]
+}
+
+//Column is a field of a flat schema
+type Column struct {
+	Name        string
+	Kind        Kind
+	Description string
+
+	value func(interface{}) interface{}
+}
+
+//Schema is an ordered set of columns describing one record type
+type Schema struct {
+	record  string
+	columns []Column
+}
+
+//Columns returns the columns of the schema in order
+func (s Schema) Columns() []Column {
+	return append([]Column(nil), s.columns...)
+}
+
+//Names returns the column names of the schema in order
+func (s Schema) Names() []string {
+	names := make([]string, len(s.columns))
+	for i, c := range s.columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+//Select returns a schema with only the named columns, in the given order
+func (s Schema) Select(names ...string) (Schema, error) {
+	selected := Schema{record: s.record}
+	for _, name := range names {
+		col, ok := s.column(strings.TrimSpace(name))
+		if !ok {
+			return Schema{}, fmt.Errorf("Unknown %s column %q", s.record, name)
+		}
+		selected.columns = append(selected.columns, col)
+	}
+	return selected, nil
+}
+
+func (s Schema) column(name string) (Column, bool) {
+	for _, c := range s.columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Column{}, false
+}
+
+//Record returns the values of a pet or shelter in column order. Values are string, int,
+//bool, []string for lists, and *float64 or *time.Time which are nil when unknown.
+func (s Schema) Record(v interface{}) ([]interface{}, error) {
+	switch v := v.(type) {
+	case *petfinder.Pet:
+		return s.Record(*v)
+	case *petfinder.Shelter:
+		return s.Record(*v)
+	case petfinder.Pet:
+		if s.record != petRecord {
+			return nil, fmt.Errorf("Cannot write a pet with the %s schema", s.record)
+		}
+	case petfinder.Shelter:
+		if s.record != shelterRecord {
+			return nil, fmt.Errorf("Cannot write a shelter with the %s schema", s.record)
+		}
+	default:
+		return nil, fmt.Errorf("Cannot export %T", v)
+	}
+
+	values := make([]interface{}, len(s.columns))
+	for i, c := range s.columns {
+		values[i] = c.value(v)
+	}
+	return values, nil
+}
+
+//phoneDescription describes the phone columns, normalized the same way for pets and shelters
+const phoneDescription = "digits, in E.164 form when the country is known, see petfinder.NormalizePhone"
+
+const (
+	petRecord     = "pet"
+	shelterRecord = "shelter"
+)
+
+func petColumn(name string, kind Kind, description string, value func(petfinder.Pet) interface{}) Column {
+	return Column{Name: name, Kind: kind, Description: description, value: func(v interface{}) interface{} {
+		return value(v.(petfinder.Pet))
+	}}
+}
+
+func shelterColumn(name string, kind Kind, description string, value func(petfinder.Shelter) interface{}) Column {
+	return Column{Name: name, Kind: kind, Description: description, value: func(v interface{}) interface{} {
+		return value(v.(petfinder.Shelter))
+	}}
+}
+
+//PetSchema returns the schema of pets
+func PetSchema() Schema {
+	return Schema{record: petRecord, columns: []Column{
+		petColumn("id", String, "Petfinder pet ID", func(p petfinder.Pet) interface{} { return p.ID }),
+		petColumn("shelter_id", String, "Petfinder shelter ID", func(p petfinder.Pet) interface{} { return p.ShelterID }),
+		petColumn("shelter_pet_id", String, "the shelter's own ID for the pet", func(p petfinder.Pet) interface{} { return p.ShelterPetID }),
+		petColumn("name", String, "", func(p petfinder.Pet) interface{} { return p.Name }),
+		petColumn("animal", String, "animal code, e.g. dog", func(p petfinder.Pet) interface{} { return p.Animal.String() }),
+		petColumn("breeds", List, "", func(p petfinder.Pet) interface{} { return list(p.Breeds) }),
+		petColumn("mix", Bool, "mixed breed", func(p petfinder.Pet) interface{} { return strings.EqualFold(p.Mix, "yes") }),
+		petColumn("age", String, "age code, e.g. Adult", func(p petfinder.Pet) interface{} { return p.Age.String() }),
+		petColumn("sex", String, "sex code, M or F", func(p petfinder.Pet) interface{} { return p.Sex.String() }),
+		petColumn("size", String, "size code, e.g. XL", func(p petfinder.Pet) interface{} { return p.Size.String() }),
+		petColumn("status", String, "status code, e.g. A for adoptable", func(p petfinder.Pet) interface{} { return p.Status.String() }),
+		petColumn("description", String, "description as plain text without boilerplate", func(p petfinder.Pet) interface{} { return p.DescriptionText() }),
+		petColumn("last_update", Time, "", func(p petfinder.Pet) interface{} { return timeValue(p.LastUpdate) }),
+		petColumn("options", List, "option codes as reported", func(p petfinder.Pet) interface{} { return list(p.Options) }),
+		petColumn("altered", Bool, "spayed or neutered", func(p petfinder.Pet) interface{} { return p.Attributes.Altered }),
+		petColumn("has_shots", Bool, "", func(p petfinder.Pet) interface{} { return p.Attributes.HasShots }),
+		petColumn("housetrained", Bool, "", func(p petfinder.Pet) interface{} { return p.Attributes.Housetrained }),
+		petColumn("no_claws", Bool, "declawed", func(p petfinder.Pet) interface{} { return p.Attributes.NoClaws }),
+		petColumn("no_cats", Bool, "not good with cats", func(p petfinder.Pet) interface{} { return p.Attributes.NoCats }),
+		petColumn("no_dogs", Bool, "not good with dogs", func(p petfinder.Pet) interface{} { return p.Attributes.NoDogs }),
+		petColumn("no_kids", Bool, "not good with children", func(p petfinder.Pet) interface{} { return p.Attributes.NoKids }),
+		petColumn("special_needs", Bool, "", func(p petfinder.Pet) interface{} { return p.Attributes.SpecialNeeds }),
+		petColumn("address1", String, "", func(p petfinder.Pet) interface{} { return p.Contact.Address1 }),
+		petColumn("address2", String, "", func(p petfinder.Pet) interface{} { return p.Contact.Address2 }),
+		petColumn("city", String, "", func(p petfinder.Pet) interface{} { return p.Contact.City }),
+		petColumn("state", String, "", func(p petfinder.Pet) interface{} { return p.Contact.State }),
+		petColumn("zip", String, "", func(p petfinder.Pet) interface{} { return p.Contact.Zip }),
+		petColumn("phone", String, phoneDescription, func(p petfinder.Pet) interface{} { return p.Contact.Normalized().Phone }),
+		petColumn("email", String, "lowercase", func(p petfinder.Pet) interface{} { return p.Contact.Normalized().Email }),
+		petColumn("fax", String, phoneDescription, func(p petfinder.Pet) interface{} { return p.Contact.Normalized().Fax }),
+		petColumn("photo_count", Int, "number of distinct photos", func(p petfinder.Pet) interface{} { return len(p.PhotoSets()) }),
+		petColumn("primary_photo_url", String, "largest size of the main photo", func(p petfinder.Pet) interface{} {
+			photo, _ := p.PrimaryPhoto()
+			return photo.URL
+		}),
+		petColumn("photo_urls", List, "largest size of every photo", func(p petfinder.Pet) interface{} {
+			var urls []string
+			for _, set := range p.PhotoSets() {
+				if photo, ok := set.Largest(); ok {
+					urls = append(urls, photo.URL)
+				}
+			}
+			return list(urls)
+		}),
+		petColumn("distance_km", Float, "distance to the shelter, see petfinder.Pets.AttachDistances", func(p petfinder.Pet) interface{} {
+			return p.Distance
+		}),
+	}}
+}
+
+//ShelterSchema returns the schema of shelters
+func ShelterSchema() Schema {
+	return Schema{record: shelterRecord, columns: []Column{
+		shelterColumn("id", String, "Petfinder shelter ID", func(s petfinder.Shelter) interface{} { return s.ID }),
+		shelterColumn("name", String, "", func(s petfinder.Shelter) interface{} { return s.Name }),
+		shelterColumn("address1", String, "", func(s petfinder.Shelter) interface{} { return s.Address1 }),
+		shelterColumn("address2", String, "", func(s petfinder.Shelter) interface{} { return s.Address2 }),
+		shelterColumn("city", String, "", func(s petfinder.Shelter) interface{} { return s.City }),
+		shelterColumn("state", String, "", func(s petfinder.Shelter) interface{} { return s.State }),
+		shelterColumn("zip", String, "", func(s petfinder.Shelter) interface{} { return s.Zip }),
+		shelterColumn("country", String, "", func(s petfinder.Shelter) interface{} { return s.Country }),
+		shelterColumn("phone", String, phoneDescription, func(s petfinder.Shelter) interface{} {
+			return s.Contact.Normalized().Phone
+		}),
+		shelterColumn("email", String, "lowercase", func(s petfinder.Shelter) interface{} { return s.Contact.Normalized().Email }),
+		shelterColumn("fax", String, phoneDescription, func(s petfinder.Shelter) interface{} {
+			return s.Contact.Normalized().Fax
+		}),
+		shelterColumn("latitude", Float, "", func(s petfinder.Shelter) interface{} {
+			if s.Coordinates == nil {
+				return (*float64)(nil)
+			}
+			lat := s.Coordinates.Lat
+			return &lat
+		}),
+		shelterColumn("longitude", Float, "", func(s petfinder.Shelter) interface{} {
+			if s.Coordinates == nil {
+				return (*float64)(nil)
+			}
+			lng := s.Coordinates.Lng
+			return &lng
+		}),
+	}}
+}
+
+//list never returns nil so lists are always encoded as arrays
+func list(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func timeValue(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
@@ -0,0 +1,184 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aouyang1/go-petfinder/petfinder"
+)
+
+func testPet() petfinder.Pet {
+	pet := petfinder.Pet{
+		ID:          "39930101",
+		Name:        "Biscuit",
+		Animal:      petfinder.AnimalDog,
+		Breeds:      []string{"Labrador Retriever", "Pit|Bull"},
+		Mix:         "yes",
+		Options:     []string{"altered", "noCats"},
+		Description: "Loves kids &amp; walks.",
+		LastUpdate:  time.Date(2018, 1, 10, 12, 21, 4, 0, time.FixedZone("CST", -6*3600)),
+	}
+	pet.Attributes = petfinder.ParseAttributes(pet.Options)
+	pet.Contact.Email = " Adopt@Rescue.org "
+	pet.Media.Photos = []petfinder.Photo{
+		{ID: "1", Size: petfinder.PhotoSizeThumbnail, URL: "http://photos/1-pnt.jpg"},
+		{ID: "1", Size: petfinder.PhotoSizeLarge, URL: "http://photos/1-x.jpg"},
+		{ID: "2", Size: petfinder.PhotoSizeLarge, URL: "http://photos/2-x.jpg"},
+	}
+	return pet
+}
+
+func TestCSVWriter(t *testing.T) {
+	schema, err := PetSchema().Select("id", "breeds", "mix", "no_cats", "email", "last_update", "photo_count", "photo_urls", "distance_km")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf, schema)
+	if err = w.Write(testPet()); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"id", "breeds", "mix", "no_cats", "email", "last_update", "photo_count", "photo_urls", "distance_km"},
+		{"39930101", `Labrador Retriever|Pit\|Bull`, "true", "true", "adopt@rescue.org", "2018-01-10T18:21:04Z", "2",
+			"http://photos/1-x.jpg|http://photos/2-x.jpg", ""},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Unexpected rows %q", rows)
+	}
+	if breeds := SplitList(rows[1][1]); !reflect.DeepEqual(breeds, testPet().Breeds) {
+		t.Errorf("Expected breeds to round trip, got %q", breeds)
+	}
+}
+
+func TestCSVWriterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewCSVWriter(&buf, ShelterSchema()).Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); !strings.HasPrefix(got, "id,name,") || strings.Count(got, "\n") != 1 {
+		t.Errorf("Expected only a header, got %q", got)
+	}
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewNDJSONWriter(&buf, PetSchema())
+	pet := testPet()
+	distance := 12.5
+	pet.Distance = &distance
+	for _, v := range []interface{}{pet, &petfinder.Pet{ID: "2"}} {
+		if err := w.Write(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], `{"id":"39930101","shelter_id":""`) {
+		t.Fatalf("Unexpected output %s", buf.String())
+	}
+
+	var first, second map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatal(err)
+	}
+	if first["description"] != "Loves kids & walks." || first["distance_km"] != 12.5 || first["photo_count"] != 2.0 {
+		t.Errorf("Unexpected record %v", first)
+	}
+	if breeds, ok := first["breeds"].([]interface{}); !ok || len(breeds) != 2 || breeds[1] != "Pit|Bull" {
+		t.Errorf("Unexpected breeds %v", first["breeds"])
+	}
+	if second["last_update"] != nil || second["distance_km"] != nil || !reflect.DeepEqual(second["breeds"], []interface{}{}) {
+		t.Errorf("Expected nulls and empty lists for missing values, got %v", second)
+	}
+	if len(first) != len(PetSchema().Columns()) {
+		t.Errorf("Expected every column, got %d", len(first))
+	}
+}
+
+func TestSchemaErrors(t *testing.T) {
+	if _, err := PetSchema().Select("id", "colour"); err == nil {
+		t.Errorf("Expected an error for an unknown column")
+	}
+	if err := NewCSVWriter(&bytes.Buffer{}, ShelterSchema()).Write(testPet()); err == nil {
+		t.Errorf("Expected an error writing a pet with the shelter schema")
+	}
+	if err := NewCSVWriter(&bytes.Buffer{}, PetSchema()).Write("pet"); err == nil {
+		t.Errorf("Expected an error writing an unsupported value")
+	}
+}
+
+func TestSplitListSingleEmptyValue(t *testing.T) {
+	if JoinList([]string{""}) != JoinList(nil) || SplitList(JoinList([]string{""})) != nil {
+		t.Errorf("Expected a single empty value to encode like an empty list")
+	}
+}
+
+func TestPhoneColumnDescriptions(t *testing.T) {
+	var descriptions []string
+	for _, schema := range []Schema{PetSchema(), ShelterSchema()} {
+		for _, c := range schema.Columns() {
+			if c.Name == "phone" || c.Name == "fax" {
+				descriptions = append(descriptions, c.Description)
+			}
+		}
+	}
+	if len(descriptions) != 4 {
+		t.Fatalf("Expected phone and fax columns in both schemas, got %d", len(descriptions))
+	}
+	for _, d := range descriptions[1:] {
+		if d != descriptions[0] {
+			t.Errorf("Expected matching phone descriptions, got %q and %q", descriptions[0], d)
+		}
+	}
+}
+
+func TestShelterRecord(t *testing.T) {
+	shelter := petfinder.Shelter{ID: "TX1203", Coordinates: &petfinder.LatLng{Lat: 33.0374, Lng: -96.7803}}
+	shelter.Phone = "(972) 555-0134"
+	shelter.Country = "US"
+
+	schema, _ := ShelterSchema().Select("phone", "latitude", "longitude")
+	values, err := schema.Record(shelter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values[0] != "+19725550134" || *values[1].(*float64) != 33.0374 || *values[2].(*float64) != -96.7803 {
+		t.Errorf("Unexpected values %v", values)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	tests := [][]string{
+		nil,
+		{"a"},
+		{"a", "b"},
+		{`back\slash`, "pi|pe", ""},
+		{"", ""},
+	}
+	for _, values := range tests {
+		if got := SplitList(JoinList(values)); !reflect.DeepEqual(got, values) {
+			t.Errorf("Expected %q to round trip, got %q", values, got)
+		}
+	}
+}
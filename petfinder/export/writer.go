@@ -0,0 +1,194 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//Writer streams records of a schema
+type Writer interface {
+	//Write writes a petfinder.Pet or petfinder.Shelter matching the schema
+	Write(v interface{}) error
+	//Flush writes any buffered data to the underlying writer
+	Flush() error
+}
+
+//CSVWriter writes records as CSV with a header row of column names
+type CSVWriter struct {
+	schema Schema
+	w      *csv.Writer
+	header bool
+}
+
+//NewCSVWriter returns a writer of CSV records. The header row is written with the first record,
+//or on Flush if there are none.
+func NewCSVWriter(w io.Writer, schema Schema) *CSVWriter {
+	return &CSVWriter{schema: schema, w: csv.NewWriter(w)}
+}
+
+//Write writes a petfinder.Pet or petfinder.Shelter as a CSV row
+func (w *CSVWriter) Write(v interface{}) error {
+	row, err := w.schema.StringRecord(v)
+	if err != nil {
+		return err
+	}
+	if err = w.writeHeader(); err != nil {
+		return err
+	}
+	return w.w.Write(row)
+}
+
+func (w *CSVWriter) writeHeader() error {
+	if w.header {
+		return nil
+	}
+	w.header = true
+	return w.w.Write(w.schema.Names())
+}
+
+//Flush writes the header if no record was written and any buffered rows
+func (w *CSVWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+//StringRecord returns the values of a pet or shelter in column order, encoded as in CSV
+func (s Schema) StringRecord(v interface{}) ([]string, error) {
+	values, err := s.Record(v)
+	if err != nil {
+		return nil, err
+	}
+	row := make([]string, len(values))
+	for i, value := range values {
+		row[i] = csvValue(value)
+	}
+	return row, nil
+}
+
+func csvValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case *float64:
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case []string:
+		return JoinList(v)
+	}
+	panic(fmt.Sprintf("export: unsupported value %T", value))
+}
+
+//listEscaper escapes the separator and escape characters of list values
+var listEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`)
+
+//JoinList encodes a list for a CSV field, joining the values with "|"
+//after escaping "|" and "\" in them with "\"
+func JoinList(values []string) string {
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = listEscaper.Replace(v)
+	}
+	return strings.Join(escaped, "|")
+}
+
+//SplitList decodes a list encoded by JoinList. An empty field is an empty list,
+//so a list of a single empty value does not round trip.
+func SplitList(field string) []string {
+	if field == "" {
+		return nil
+	}
+
+	var values []string
+	var b strings.Builder
+	escaped := false
+	for _, r := range field {
+		switch {
+		case escaped:
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '|':
+			values = append(values, b.String())
+			b.Reset()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return append(values, b.String())
+}
+
+//NDJSONWriter writes records as JSON objects, one per line, with keys in column order
+type NDJSONWriter struct {
+	schema Schema
+	w      *bufio.Writer
+	keys   [][]byte
+}
+
+//NewNDJSONWriter returns a writer of newline delimited JSON records
+func NewNDJSONWriter(w io.Writer, schema Schema) *NDJSONWriter {
+	keys := make([][]byte, len(schema.columns))
+	for i, name := range schema.Names() {
+		keys[i], _ = json.Marshal(name)
+	}
+	return &NDJSONWriter{schema: schema, w: bufio.NewWriter(w), keys: keys}
+}
+
+//Write writes a petfinder.Pet or petfinder.Shelter as a JSON object on its own line
+func (w *NDJSONWriter) Write(v interface{}) error {
+	values, err := w.schema.Record(v)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(w.keys[i])
+		buf.WriteByte(':')
+		encoded, err := json.Marshal(jsonValue(value))
+		if err != nil {
+			return err
+		}
+		buf.Write(encoded)
+	}
+	buf.WriteString("}\n")
+	_, err = w.w.Write(buf.Bytes())
+	return err
+}
+
+//Flush writes any buffered records
+func (w *NDJSONWriter) Flush() error {
+	return w.w.Flush()
+}
+
+func jsonValue(value interface{}) interface{} {
+	if t, ok := value.(*time.Time); ok && t != nil {
+		return t.Format(time.RFC3339)
+	}
+	return value
+}
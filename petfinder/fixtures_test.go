@@ -0,0 +1,212 @@
+package petfinder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aouyang1/go-petfinder/petfinder/petfindertest"
+)
+
+func newFixtureClient(t *testing.T) (Client, *petfindertest.Server) {
+	srv := petfindertest.NewServer()
+	t.Cleanup(srv.Close)
+
+	c := NewClient("key", WithBaseURL(srv.URL), WithInsecureHTTP())
+	return c, srv
+}
+
+func TestFixtureListBreeds(t *testing.T) {
+	c, _ := newFixtureClient(t)
+	breeds, err := c.ListBreeds(Options{Animal: "dog"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(breeds) != 5 || breeds[0] != "Affenpinscher" {
+		t.Errorf("Unexpected breeds %v", breeds)
+	}
+}
+
+func TestFixtureGetRandomPetID(t *testing.T) {
+	c, _ := newFixtureClient(t)
+	id, err := c.GetRandomPetID(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "39930101" {
+		t.Errorf("Unexpected id %q", id)
+	}
+}
+
+func TestFixtureGetPet(t *testing.T) {
+	c, _ := newFixtureClient(t)
+	for _, get := range []func() (Pet, error){
+		func() (Pet, error) { return c.GetPet(Options{ID: "39930101"}) },
+		func() (Pet, error) { return c.GetRandomPet(Options{Output: "full"}) },
+	} {
+		pet, err := get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pet.ID != "39930101" || pet.Name != "Biscuit" || pet.ShelterID != "TX1203" {
+			t.Errorf("Unexpected pet %+v", pet)
+		}
+		if len(pet.Breeds) != 2 || pet.Breeds[1] != "Beagle" {
+			t.Errorf("Unexpected breeds %v", pet.Breeds)
+		}
+		if len(pet.Options) != 4 || pet.Options[0] != "hasShots" {
+			t.Errorf("Unexpected options %v", pet.Options)
+		}
+		if len(pet.Media.Photos) != 10 || pet.Media.Photos[0].Size != "x" || pet.Media.Photos[0].ID != "1" {
+			t.Errorf("Unexpected photos %+v", pet.Media.Photos)
+		}
+		if pet.Animal != AnimalDog || pet.Sex != SexMale || pet.Age != AgeAdult || pet.Status != PetStatusAdoptable {
+			t.Errorf("Unexpected enumerated fields %+v", pet)
+		}
+		if pet.Contact.City != "Plano" || pet.LastUpdate.IsZero() {
+			t.Errorf("Unexpected contact or last update %+v", pet)
+		}
+	}
+}
+
+func TestFixtureFindPet(t *testing.T) {
+	c, srv := newFixtureClient(t)
+	tests := []struct {
+		fixture string
+		ids     []string
+	}{
+		{"pet.find", []string{"39930101", "39930102", "39930103"}},
+		{"pet.find.single", []string{"39930102"}},
+		{"pet.find.empty", nil},
+	}
+
+	for _, tt := range tests {
+		srv.SetFixture("pet.find", tt.fixture)
+		pets, err := c.FindPet(Options{Location: "75093"})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.fixture, err)
+		}
+		if len(pets) != len(tt.ids) {
+			t.Fatalf("%s: expected %d pets, got %d", tt.fixture, len(tt.ids), len(pets))
+		}
+		for i, pet := range pets {
+			if pet.ID != tt.ids[i] {
+				t.Errorf("%s: expected pet %s, got %s", tt.fixture, tt.ids[i], pet.ID)
+			}
+		}
+	}
+
+	// single breed and option objects rather than arrays
+	srv.SetFixture("pet.find", "pet.find")
+	pets, err := c.FindPet(Options{Location: "75093"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pets[1].Breeds) != 1 || pets[1].Breeds[0] != "Domestic Short Hair" {
+		t.Errorf("Unexpected single breed %v", pets[1].Breeds)
+	}
+	if len(pets[1].Options) != 1 || pets[1].Options[0] != "altered" {
+		t.Errorf("Unexpected single option %v", pets[1].Options)
+	}
+	if len(pets[2].Options) != 0 || len(pets[2].Media.Photos) != 0 {
+		t.Errorf("Expected no options or photos, got %+v", pets[2])
+	}
+}
+
+func TestFixtureShelters(t *testing.T) {
+	c, srv := newFixtureClient(t)
+
+	shelters, err := c.FindShelter(Options{Location: "75093"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shelters) != 2 || shelters[1].ID != "TX1577" || shelters[1].Address2 != "Suite 100" {
+		t.Errorf("Unexpected shelters %+v", shelters)
+	}
+
+	srv.SetFixture("shelter.find", "shelter.find.single")
+	shelters, err = c.FindShelter(Options{Location: "75093"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shelters) != 1 || shelters[0].ID != "TX1203" {
+		t.Errorf("Unexpected shelters %+v", shelters)
+	}
+
+	shelter, err := c.GetShelter(Options{ID: "TX1203"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shelter.Name != "North Texas Rescue" || shelter.Latitude != "33.0374" {
+		t.Errorf("Unexpected shelter %+v", shelter)
+	}
+
+	pets, err := c.GetShelterPets(Options{ID: "TX1203"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pets) != 2 {
+		t.Errorf("Expected 2 shelter pets, got %d", len(pets))
+	}
+}
+
+func TestFixtureErrors(t *testing.T) {
+	c, srv := newFixtureClient(t)
+	tests := []struct {
+		fixture  string
+		sentinel error
+	}{
+		{"error.notfound", ErrNotFound},
+		{"error.unauthorized", ErrUnauthorized},
+		{"error.limit", ErrRateLimited},
+		{"error.invalid", ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		srv.SetFixture("pet.get", tt.fixture)
+		_, err := c.GetPet(Options{ID: "1"})
+		if !errors.Is(err, tt.sentinel) {
+			t.Errorf("%s: expected %v, got %v", tt.fixture, tt.sentinel, err)
+		}
+	}
+
+	c.apiKey = ""
+	if _, err := c.GetShelter(Options{ID: "TX1203"}); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected unauthorized without a key, got %v", err)
+	}
+}
+
+func TestFixtureHTTPClient(t *testing.T) {
+	srv := petfindertest.NewServer()
+	defer srv.Close()
+
+	c := NewClient("key")
+	c.HTTPClient = srv.HTTPClient()
+	if _, err := c.GetShelter(Options{ID: "TX1203"}); err != nil {
+		t.Fatal(err)
+	}
+	if reqs := srv.Requests(); len(reqs) != 1 || reqs[0].Path != "/shelter.get" {
+		t.Errorf("Unexpected requests %v", reqs)
+	}
+}
+
+func TestFixtureListSheltersByBreed(t *testing.T) {
+	c, srv := newFixtureClient(t)
+
+	shelters, err := c.ListSheltersByBreed(Options{Animal: "dog", Breed: "Akita", Count: 2, Offset: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shelters) != 2 || shelters[0].ID != "TX1203" {
+		t.Errorf("Unexpected shelters %+v", shelters)
+	}
+	q := srv.Requests()[0].Query()
+	if q.Get("animal") != "dog" || q.Get("breed") != "Akita" || q.Get("offset") != "4" || q.Get("count") != "2" {
+		t.Errorf("Unexpected query %v", q)
+	}
+
+	_, err = c.ListSheltersByBreed(Options{Animal: "dog", Location: "75093"})
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) || len(valErr.Fields) != 2 {
+		t.Errorf("Expected missing Breed and forbidden Location, got %v", err)
+	}
+}
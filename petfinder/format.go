@@ -0,0 +1,241 @@
+package petfinder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+//Format is the response format requested from the API
+type Format string
+
+//Response formats served by the v1 API
+const (
+	JSON Format = "json"
+	XML  Format = "xml"
+)
+
+//WithFormat requests responses in the given format. Both formats decode into the same types,
+//so switching to XML works around decoding problems with the "$t" wrapped JSON rendering.
+func WithFormat(format Format) ClientOption {
+	return func(c *Client) {
+		c.format = format
+	}
+}
+
+func (f Format) unmarshal(body []byte, v interface{}) error {
+	if f == XML {
+		return unmarshalXML(body, v)
+	}
+	return json.Unmarshal(body, v)
+}
+
+//unmarshalXML decodes an XML document. The API declares its documents as ISO-8859-1,
+//which encoding/xml does not support on its own.
+func unmarshalXML(body []byte, v interface{}) error {
+	d := xml.NewDecoder(bytes.NewReader(body))
+	d.CharsetReader = charsetReader
+	return d.Decode(v)
+}
+
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "latin-1":
+		return latin1Reader{bufio.NewReader(input)}, nil
+	}
+	return nil, fmt.Errorf("Unsupported XML charset %q", charset)
+}
+
+//latin1Reader transcodes ISO-8859-1 bytes, each a code point below 256, to UTF-8
+type latin1Reader struct {
+	r io.ByteReader
+}
+
+func (l latin1Reader) Read(p []byte) (int, error) {
+	// code points below 256 encode to at most two bytes
+	n := 0
+	for n+2 <= len(p) {
+		b, err := l.r.ReadByte()
+		if err != nil {
+			return n, err
+		}
+		n += utf8.EncodeRune(p[n:], rune(b))
+	}
+	return n, nil
+}
+
+//header decodes the header of a response, ok is false if the body could not be decoded
+func (f Format) header(body []byte) (header, bool) {
+	if f == XML {
+		var headerResp headerXMLResponse
+		err := unmarshalXML(body, &headerResp)
+		return headerResp.Header, err == nil
+	}
+	var headerResp headerResponse
+	err := json.Unmarshal(body, &headerResp)
+	return headerResp.Petfinder.Header, err == nil
+}
+
+func (f Format) decodePetFind(body []byte) (Pets, int, error) {
+	if f == XML {
+		return decodePetFindXML(body)
+	}
+	return decodePetFind(body)
+}
+
+func (f Format) decodeShelterFind(body []byte) (Shelters, int, error) {
+	if f == XML {
+		return decodeShelterFindXML(body)
+	}
+	return decodeShelterFind(body)
+}
+
+//XML responses are rooted at a <petfinder> element holding the same elements as the JSON "petfinder" object
+
+type headerXMLResponse struct {
+	Header header `xml:"header"`
+}
+
+type breedListXMLResponse struct {
+	Breeds struct {
+		Breed  textList `xml:"breed"`
+		Animal string   `xml:"animal,attr"`
+	} `xml:"breeds"`
+	Header header `xml:"header"`
+}
+
+type petIDXMLResponse struct {
+	ID     string `xml:"petIds>id"`
+	Header header `xml:"header"`
+}
+
+type petXMLResponse struct {
+	Pet    petSingle `xml:"pet"`
+	Header header    `xml:"header"`
+}
+
+type petFindXMLResponse struct {
+	LastOffset string      `xml:"lastOffset"`
+	Pets       []petSingle `xml:"pets>pet"`
+	Header     header      `xml:"header"`
+}
+
+type shelterXMLResponse struct {
+	Shelter shelterSingle `xml:"shelter"`
+	Header  header        `xml:"header"`
+}
+
+type shelterFindXMLResponse struct {
+	LastOffset string          `xml:"lastOffset"`
+	Shelters   []shelterSingle `xml:"shelters>shelter"`
+	Header     header          `xml:"header"`
+}
+
+//UnmarshalXML is a custom unmarshaller for Breeds
+func (b *Breeds) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var breedList breedListXMLResponse
+	if err := d.DecodeElement(&breedList, &start); err != nil {
+		return err
+	}
+	*b = append(*b, breedList.Breeds.Breed...)
+	return nil
+}
+
+//UnmarshalXML is a custom unmarshaller for petID
+func (id *petID) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var petIDResp petIDXMLResponse
+	if err := d.DecodeElement(&petIDResp, &start); err != nil {
+		return err
+	}
+	*id = petID(petIDResp.ID)
+	return nil
+}
+
+//UnmarshalXML is a custom unmarshaller for Pet
+func (p *Pet) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var petResp petXMLResponse
+	if err := d.DecodeElement(&petResp, &start); err != nil {
+		return err
+	}
+	p.mapPetResponse(petResp.Pet)
+	return nil
+}
+
+//UnmarshalXML is a custom unmarshaller for Pets
+func (p *Pets) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var petFindResp petFindXMLResponse
+	if err := d.DecodeElement(&petFindResp, &start); err != nil {
+		return err
+	}
+	*p = append(*p, mapPetFindXML(petFindResp)...)
+	return nil
+}
+
+func decodePetFindXML(buf []byte) (Pets, int, error) {
+	var petFindResp petFindXMLResponse
+	if err := unmarshalXML(buf, &petFindResp); err != nil {
+		return nil, 0, err
+	}
+	lastOffset, err := parseOffset(petFindResp.LastOffset)
+	if err != nil {
+		return nil, 0, err
+	}
+	return mapPetFindXML(petFindResp), lastOffset, nil
+}
+
+func mapPetFindXML(petFindResp petFindXMLResponse) Pets {
+	pets := make(Pets, 0, len(petFindResp.Pets))
+	for _, petR := range petFindResp.Pets {
+		pet := Pet{}
+		pet.mapPetResponse(petR)
+		pets = append(pets, pet)
+	}
+	return pets
+}
+
+//UnmarshalXML is a custom unmarshaller for Shelter
+func (s *Shelter) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var shelterResp shelterXMLResponse
+	if err := d.DecodeElement(&shelterResp, &start); err != nil {
+		return err
+	}
+	s.mapShelterResponse(shelterResp.Shelter)
+	return nil
+}
+
+//UnmarshalXML is a custom unmarshaller for Shelters
+func (s *Shelters) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var shelterFindResp shelterFindXMLResponse
+	if err := d.DecodeElement(&shelterFindResp, &start); err != nil {
+		return err
+	}
+	*s = append(*s, mapShelterFindXML(shelterFindResp)...)
+	return nil
+}
+
+func decodeShelterFindXML(buf []byte) (Shelters, int, error) {
+	var shelterFindResp shelterFindXMLResponse
+	if err := unmarshalXML(buf, &shelterFindResp); err != nil {
+		return nil, 0, err
+	}
+	lastOffset, err := parseOffset(shelterFindResp.LastOffset)
+	if err != nil {
+		return nil, 0, err
+	}
+	return mapShelterFindXML(shelterFindResp), lastOffset, nil
+}
+
+func mapShelterFindXML(shelterFindResp shelterFindXMLResponse) Shelters {
+	shelters := make(Shelters, 0, len(shelterFindResp.Shelters))
+	for _, shelterR := range shelterFindResp.Shelters {
+		shelter := Shelter{}
+		shelter.mapShelterResponse(shelterR)
+		shelters = append(shelters, shelter)
+	}
+	return shelters
+}
@@ -0,0 +1,73 @@
+package petfinder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aouyang1/go-petfinder/petfinder/petfindertest"
+)
+
+func TestXMLFormat(t *testing.T) {
+	srv := petfindertest.NewServer()
+	defer srv.Close()
+
+	jsonClient := NewClient("key", WithBaseURL(srv.URL), WithInsecureHTTP())
+	xmlClient := NewClient("key", WithBaseURL(srv.URL), WithInsecureHTTP(), WithFormat(XML))
+
+	jsonPet, err := jsonClient.GetPet(Options{ID: "39930101"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	xmlPet, err := xmlClient.GetPet(Options{ID: "39930101"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(jsonPet, xmlPet) {
+		t.Errorf("XML pet differs from JSON pet:\n%+v\n%+v", xmlPet, jsonPet)
+	}
+
+	for _, fixture := range []string{"pet.find", "pet.find.single"} {
+		srv.SetResponse("pet.find", petfindertest.XMLFixture(fixture))
+		pets, err := xmlClient.FindPet(Options{Location: "75093"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		srv.SetFixture("pet.find", fixture)
+		want, _ := jsonClient.FindPet(Options{Location: "75093"})
+		if !reflect.DeepEqual(pets, want) {
+			t.Errorf("%s: XML pets differ from JSON pets", fixture)
+		}
+	}
+
+	shelters, err := xmlClient.FindShelter(Options{Location: "75093"})
+	if err != nil || len(shelters) != 2 || shelters[1].Address2 != "Suite 100" {
+		t.Errorf("Unexpected XML shelters %+v, %v", shelters, err)
+	}
+	shelter, err := xmlClient.GetShelter(Options{ID: "TX1203"})
+	if err != nil || shelter.Latitude != "33.0374" {
+		t.Errorf("Unexpected XML shelter %+v, %v", shelter, err)
+	}
+	breeds, err := xmlClient.ListBreeds(Options{Animal: "dog"})
+	if err != nil || len(breeds) != 5 {
+		t.Errorf("Unexpected XML breeds %v, %v", breeds, err)
+	}
+	id, err := xmlClient.GetRandomPetID(Options{})
+	if err != nil || id != "39930101" {
+		t.Errorf("Unexpected XML pet id %q, %v", id, err)
+	}
+
+	it := xmlClient.FindShelterIter(Options{Location: "75093", Count: 3})
+	var n int
+	for it.Next() {
+		n++
+	}
+	if it.Err() != nil || n != 2 {
+		t.Errorf("Expected 2 shelters from XML iterator, got %d, %v", n, it.Err())
+	}
+
+	srv.SetResponse("pet.get", petfindertest.XMLFixture("error.notfound"))
+	if _, err := xmlClient.GetPet(Options{ID: "1"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected not found from XML header, got %v", err)
+	}
+}
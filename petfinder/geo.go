@@ -0,0 +1,107 @@
+package petfinder
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//earthRadiusKm is the mean radius of the earth used by the haversine formula
+const earthRadiusKm = 6371.0
+
+//KmPerMile converts distances in kilometers to miles
+const KmPerMile = 1.609344
+
+//LatLng is a point given by its latitude and longitude in degrees
+type LatLng struct {
+	Lat float64
+	Lng float64
+}
+
+//ParseLatLng parses a latitude and longitude given as decimal degree strings
+func ParseLatLng(lat, lng string) (LatLng, error) {
+	la, ok := parseDegrees(lat, 90)
+	if !ok {
+		return LatLng{}, fmt.Errorf("Invalid latitude %q", lat)
+	}
+	lo, ok := parseDegrees(lng, 180)
+	if !ok {
+		return LatLng{}, fmt.Errorf("Invalid longitude %q", lng)
+	}
+	return LatLng{Lat: la, Lng: lo}, nil
+}
+
+//parseDegrees parses a finite number of degrees within [-limit, limit]
+func parseDegrees(s string, limit float64) (float64, bool) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) || v < -limit || v > limit {
+		return 0, false
+	}
+	return v, true
+}
+
+//Distance returns the great-circle distance in kilometers to another point using the haversine formula
+func (l LatLng) Distance(to LatLng) float64 {
+	lat1 := l.Lat * math.Pi / 180
+	lat2 := to.Lat * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (to.Lng - l.Lng) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+//Distance returns the distance in kilometers from a point to the shelter.
+//ok is false if the shelter has no valid coordinates.
+func (s Shelter) Distance(from LatLng) (km float64, ok bool) {
+	if s.Coordinates == nil {
+		return 0, false
+	}
+	return from.Distance(*s.Coordinates), true
+}
+
+//SortByDistance sorts the shelters nearest first from a point.
+//Shelters without coordinates are placed last in their original order.
+func (s Shelters) SortByDistance(from LatLng) {
+	sort.SliceStable(s, func(i, j int) bool {
+		di, oki := s[i].Distance(from)
+		dj, okj := s[j].Distance(from)
+		if oki != okj {
+			return oki
+		}
+		return oki && di < dj
+	})
+}
+
+//AttachDistances sets the Distance of every pet to the distance in kilometers from a point
+//to its shelter. Pets whose shelter is not among the given shelters or has no coordinates get a nil Distance.
+func (p Pets) AttachDistances(shelters Shelters, from LatLng) {
+	distances := make(map[string]float64, len(shelters))
+	for _, s := range shelters {
+		if d, ok := s.Distance(from); ok {
+			distances[s.ID] = d
+		}
+	}
+
+	for i := range p {
+		p[i].Distance = nil
+		if d, ok := distances[p[i].ShelterID]; ok {
+			d := d
+			p[i].Distance = &d
+		}
+	}
+}
+
+//SortByDistance sorts the pets nearest first by their attached Distance.
+//Pets without a distance are placed last in their original order.
+func (p Pets) SortByDistance() {
+	sort.SliceStable(p, func(i, j int) bool {
+		di, dj := p[i].Distance, p[j].Distance
+		if (di == nil) != (dj == nil) {
+			return di != nil
+		}
+		return di != nil && *di < *dj
+	})
+}
@@ -0,0 +1,61 @@
+package petfinder
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLatLngDistance(t *testing.T) {
+	sf := LatLng{Lat: 37.7749, Lng: -122.4194}
+	la := LatLng{Lat: 34.0522, Lng: -118.2437}
+	if d := sf.Distance(la); math.Abs(d-559.1) > 1 {
+		t.Errorf("Expected about 559km from San Francisco to Los Angeles, got %.1f", d)
+	}
+	if d := sf.Distance(sf); d != 0 {
+		t.Errorf("Expected zero distance to self, got %v", d)
+	}
+
+	if _, err := ParseLatLng("91", "0"); err == nil {
+		t.Errorf("Expected out of range latitude to fail")
+	}
+	if _, err := ParseLatLng("", "-96.7803"); err == nil {
+		t.Errorf("Expected missing latitude to fail")
+	}
+	for _, v := range []string{"NaN", "nan", "Inf", "-Infinity"} {
+		if _, err := ParseLatLng(v, "0"); err == nil {
+			t.Errorf("Expected latitude %s to fail", v)
+		}
+		if _, err := ParseLatLng("0", v); err == nil {
+			t.Errorf("Expected longitude %s to fail", v)
+		}
+	}
+}
+
+func TestSortByDistance(t *testing.T) {
+	c, _ := newFixtureClient(t)
+	shelters, err := c.FindShelter(Options{Location: "75093"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	shelters = append(Shelters{{ID: "NOWHERE"}}, shelters...)
+
+	// closer to McKinney than Plano
+	from := LatLng{Lat: 33.20, Lng: -96.63}
+	shelters.SortByDistance(from)
+	if shelters[0].ID != "TX1577" || shelters[1].ID != "TX1203" || shelters[2].ID != "NOWHERE" {
+		t.Errorf("Unexpected order %s, %s, %s", shelters[0].ID, shelters[1].ID, shelters[2].ID)
+	}
+	if shelters[1].Latitude != "33.0374" || shelters[1].Coordinates.Lat != 33.0374 {
+		t.Errorf("Expected raw and parsed coordinates, got %+v", shelters[1])
+	}
+
+	pets := Pets{{ID: "a", ShelterID: "TX1203"}, {ID: "b", ShelterID: "NOWHERE"}, {ID: "c", ShelterID: "TX1577"}}
+	pets.AttachDistances(shelters, from)
+	pets.SortByDistance()
+	if pets[0].ID != "c" || pets[1].ID != "a" || pets[2].Distance != nil {
+		t.Errorf("Unexpected pet order %s, %s, %s", pets[0].ID, pets[1].ID, pets[2].ID)
+	}
+	if d, _ := shelters[0].Distance(from); *pets[0].Distance != d {
+		t.Errorf("Expected pet distance %v, got %v", d, *pets[0].Distance)
+	}
+}
@@ -6,17 +6,17 @@ import (
 
 type header struct {
 	Timestamp struct {
-		T time.Time `json:"$t"`
-	} `json:"timestamp"`
+		T time.Time `json:"$t" xml:",chardata"`
+	} `json:"timestamp" xml:"timestamp"`
 	Status struct {
 		Message struct {
-			T string `json:"$t"`
-		} `json:"message"`
+			T string `json:"$t" xml:",chardata"`
+		} `json:"message" xml:"message"`
 		Code struct {
-			T string `json:"$t"`
-		} `json:"code"`
-	} `json:"status"`
+			T string `json:"$t" xml:",chardata"`
+		} `json:"code" xml:"code"`
+	} `json:"status" xml:"status"`
 	Version struct {
-		T string `json:"$t"`
-	} `json:"version"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"version" xml:"version"`
 }
@@ -0,0 +1,223 @@
+package petfinder
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+//defaultPageSize is the number of records the API returns when count is not specified
+const defaultPageSize = 25
+
+func parseOffset(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid lastOffset %q", s)
+	}
+	return offset, nil
+}
+
+//pager tracks the offset of a paginated API method and fetches pages on demand
+type pager struct {
+	ctx       context.Context
+	c         Client
+	apiMethod string
+	opt       Options
+	done      bool
+	err       error
+}
+
+func newPager(ctx context.Context, c Client, apiMethod string, opt Options) pager {
+	if opt.Count <= 0 {
+		opt.Count = defaultPageSize
+	}
+	return pager{ctx: ctx, c: c, apiMethod: apiMethod, opt: opt}
+}
+
+//fetch retrieves the next page and decodes it with the given function
+func (pg *pager) fetch(decode func([]byte) (int, int, error)) {
+	body, err := pg.c.submitRequest(pg.ctx, pg.apiMethod, pg.opt)
+	if err != nil {
+		pg.err = err
+		return
+	}
+
+	n, lastOffset, err := decode(body)
+	if err != nil {
+		pg.c.log(pg.ctx, LogEvent{Kind: EventDecodeError, Method: pg.apiMethod, Err: err})
+		pg.err = err
+		return
+	}
+
+	// a short page or an offset that did not advance means there are no more results
+	if n < pg.opt.Count || lastOffset <= pg.opt.Offset {
+		pg.done = true
+	}
+	pg.opt.Offset = lastOffset
+}
+
+//PetIterator lazily pages through the results of a pet search
+type PetIterator struct {
+	pager
+	page Pets
+	pet  Pet
+}
+
+//Next advances the iterator to the next Pet, fetching another page when needed.
+//It returns false when the results are exhausted or an error occurred.
+func (it *PetIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch(func(body []byte) (int, int, error) {
+			pets, lastOffset, err := it.c.format.decodePetFind(body)
+			it.page = pets
+			return len(pets), lastOffset, err
+		})
+	}
+	it.pet, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+//Pet returns the current Pet
+func (it *PetIterator) Pet() Pet {
+	return it.pet
+}
+
+//Err returns the first error encountered while paging
+func (it *PetIterator) Err() error {
+	return it.err
+}
+
+//ShelterIterator lazily pages through the results of a shelter search
+type ShelterIterator struct {
+	pager
+	page    Shelters
+	shelter Shelter
+}
+
+//Next advances the iterator to the next Shelter, fetching another page when needed.
+//It returns false when the results are exhausted or an error occurred.
+func (it *ShelterIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch(func(body []byte) (int, int, error) {
+			shelters, lastOffset, err := it.c.format.decodeShelterFind(body)
+			it.page = shelters
+			return len(shelters), lastOffset, err
+		})
+	}
+	it.shelter, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+//Shelter returns the current Shelter
+func (it *ShelterIterator) Shelter() Shelter {
+	return it.shelter
+}
+
+//Err returns the first error encountered while paging
+func (it *ShelterIterator) Err() error {
+	return it.err
+}
+
+//FindPetIter returns an iterator over every Pet matching the search options.
+//Pages of opt.Count pets (25 if unset) are fetched starting at opt.Offset as the iterator advances.
+//location option must be specified with represents a zip code or city/state
+func (c Client) FindPetIter(opt Options) *PetIterator {
+	return c.FindPetIterContext(context.Background(), opt)
+}
+
+//FindPetIterContext is FindPetIter with a context controlling cancellation of every page request
+func (c Client) FindPetIterContext(ctx context.Context, opt Options) *PetIterator {
+	return &PetIterator{pager: newPager(ctx, c, "pet.find", opt)}
+}
+
+//FindPetPage returns a single page of pets matching the search options along with the
+//lastOffset reported by the API, which is the Offset to request the following page with.
+//location option must be specified with represents a zip code or city/state
+func (c Client) FindPetPage(opt Options) (Pets, int, error) {
+	return c.FindPetPageContext(context.Background(), opt)
+}
+
+//FindPetPageContext is FindPetPage with a context controlling cancellation of the request
+func (c Client) FindPetPageContext(ctx context.Context, opt Options) (Pets, int, error) {
+	body, err := c.submitRequest(ctx, "pet.find", opt)
+	if err != nil {
+		return nil, 0, err
+	}
+	pets, lastOffset, err := c.format.decodePetFind(body)
+	if err != nil {
+		c.log(ctx, LogEvent{Kind: EventDecodeError, Method: "pet.find", Err: err})
+	}
+	return pets, lastOffset, err
+}
+
+//FindShelterIter returns an iterator over every Shelter matching the search options.
+//Pages of opt.Count shelters (25 if unset) are fetched starting at opt.Offset as the iterator advances.
+//location option must be specified with represents a zip code or city/state
+func (c Client) FindShelterIter(opt Options) *ShelterIterator {
+	return c.FindShelterIterContext(context.Background(), opt)
+}
+
+//FindShelterIterContext is FindShelterIter with a context controlling cancellation of every page request
+func (c Client) FindShelterIterContext(ctx context.Context, opt Options) *ShelterIterator {
+	return &ShelterIterator{pager: newPager(ctx, c, "shelter.find", opt)}
+}
+
+//FindShelterPage returns a single page of shelters matching the search options along with the
+//lastOffset reported by the API, which is the Offset to request the following page with.
+//location option must be specified with represents a zip code or city/state
+func (c Client) FindShelterPage(opt Options) (Shelters, int, error) {
+	return c.FindShelterPageContext(context.Background(), opt)
+}
+
+//FindShelterPageContext is FindShelterPage with a context controlling cancellation of the request
+func (c Client) FindShelterPageContext(ctx context.Context, opt Options) (Shelters, int, error) {
+	body, err := c.submitRequest(ctx, "shelter.find", opt)
+	if err != nil {
+		return nil, 0, err
+	}
+	shelters, lastOffset, err := c.format.decodeShelterFind(body)
+	if err != nil {
+		c.log(ctx, LogEvent{Kind: EventDecodeError, Method: "shelter.find", Err: err})
+	}
+	return shelters, lastOffset, err
+}
+
+//ListSheltersByBreedIter returns an iterator over every Shelter with pets of the given animal and breed.
+//animal and breed options must be specified
+func (c Client) ListSheltersByBreedIter(opt Options) *ShelterIterator {
+	return c.ListSheltersByBreedIterContext(context.Background(), opt)
+}
+
+//ListSheltersByBreedIterContext is ListSheltersByBreedIter with a context controlling cancellation of every page request
+func (c Client) ListSheltersByBreedIterContext(ctx context.Context, opt Options) *ShelterIterator {
+	return &ShelterIterator{pager: newPager(ctx, c, "shelter.listByBreed", opt)}
+}
+
+//FindPetWhere pages through the results of a pet search and returns the pets matching the predicate,
+//stopping once limit matches were collected or the results are exhausted. A limit of 0 or less collects every match.
+//Pets matched before an error occurred are returned along with the error.
+//location option must be specified with represents a zip code or city/state
+func (c Client) FindPetWhere(opt Options, pred Predicate, limit int) (Pets, error) {
+	return c.FindPetWhereContext(context.Background(), opt, pred, limit)
+}
+
+//FindPetWhereContext is FindPetWhere with a context controlling cancellation of every page request
+func (c Client) FindPetWhereContext(ctx context.Context, opt Options, pred Predicate, limit int) (Pets, error) {
+	var pets Pets
+	it := c.FindPetIterContext(ctx, opt)
+	for (limit <= 0 || len(pets) < limit) && it.Next() {
+		if pred == nil || pred(it.Pet()) {
+			pets = append(pets, it.Pet())
+		}
+	}
+	return pets, it.Err()
+}
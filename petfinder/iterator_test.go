@@ -0,0 +1,123 @@
+package petfinder
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestFindPetIter(t *testing.T) {
+	const total = 5
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+
+		var pets []string
+		for i := offset; i < offset+count && i < total; i++ {
+			pets = append(pets, fmt.Sprintf(`{"id":{"$t":"%d"}}`, i))
+		}
+		petJSON := "[" + strings.Join(pets, ",") + "]"
+		if len(pets) == 1 {
+			petJSON = pets[0]
+		}
+		fmt.Fprintf(w, `{"petfinder":{"lastOffset":{"$t":"%d"},"pets":{"pet":%s},"header":{"status":{"code":{"$t":"100"}}}}}`,
+			offset+len(pets), petJSON)
+	}))
+	defer ts.Close()
+
+	c := NewClient("key", WithBaseURL(ts.URL), WithInsecureHTTP())
+
+	it := c.FindPetIter(Options{Location: "94041", Count: 2})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Pet().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Join(ids, ","); got != "0,1,2,3,4" {
+		t.Errorf("Expected pets 0-4, got %s", got)
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 page requests, got %d", requests)
+	}
+}
+
+func TestFindPetWhere(t *testing.T) {
+	const total = 7
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+
+		var pets []string
+		for i := offset; i < offset+count && i < total; i++ {
+			mix := "no"
+			if i%3 == 0 {
+				mix = "yes"
+			}
+			pets = append(pets, fmt.Sprintf(`{"id":{"$t":"%d"},"mix":{"$t":"%s"}}`, i, mix))
+		}
+		fmt.Fprintf(w, `{"petfinder":{"lastOffset":{"$t":"%d"},"pets":{"pet":[%s]},"header":{"status":{"code":{"$t":"100"}}}}}`,
+			offset+len(pets), strings.Join(pets, ","))
+	}))
+	defer ts.Close()
+
+	c := NewClient("key", WithBaseURL(ts.URL), WithInsecureHTTP())
+	tests := []struct {
+		limit    int
+		ids      string
+		requests int
+	}{
+		{2, "0,3", 2},
+		{0, "0,3,6", 4},
+	}
+	for _, tt := range tests {
+		requests = 0
+		pets, err := c.FindPetWhere(Options{Location: "94041", Count: 2}, Mix(true), tt.limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var ids []string
+		for _, pet := range pets {
+			ids = append(ids, pet.ID)
+		}
+		if got := strings.Join(ids, ","); got != tt.ids {
+			t.Errorf("Expected pets %s with limit %d, got %s", tt.ids, tt.limit, got)
+		}
+		if requests != tt.requests {
+			t.Errorf("Expected %d page requests with limit %d, got %d", tt.requests, tt.limit, requests)
+		}
+	}
+}
+
+func TestFindPage(t *testing.T) {
+	c, srv := newFixtureClient(t)
+	xc := NewClient("key", WithBaseURL(srv.URL), WithInsecureHTTP(), WithFormat(XML))
+
+	for _, client := range []Client{c, xc} {
+		pets, lastOffset, err := client.FindPetPage(Options{Location: "75024"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pets) != 3 || lastOffset != 3 {
+			t.Errorf("Expected 3 pets and lastOffset 3, got %d and %d", len(pets), lastOffset)
+		}
+
+		shelters, lastOffset, err := client.FindShelterPage(Options{Location: "75024"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(shelters) != 2 || lastOffset != 2 {
+			t.Errorf("Expected 2 shelters and lastOffset 2, got %d and %d", len(shelters), lastOffset)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package petfinder
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"time"
+)
+
+//EventKind identifies the stage of a request a LogEvent describes
+type EventKind string
+
+//Events emitted to a Client's Logger
+const (
+	EventRequest     EventKind = "request"      // an attempt is about to be sent
+	EventResponse    EventKind = "response"     // an attempt completed, successfully or not
+	EventRetry       EventKind = "retry"        // a failed attempt will be retried after Wait
+	EventDecodeError EventKind = "decode_error" // a response body could not be decoded
+)
+
+//LogEvent is a structured record of something that happened while serving a Client call
+type LogEvent struct {
+	Kind       EventKind
+	Method     string        // Petfinder API method, e.g. pet.find
+	URL        string        // request URL with the API key redacted
+	Attempt    int           // attempt number starting at 1
+	Duration   time.Duration // time taken by the attempt
+	Wait       time.Duration // delay before the next attempt
+	StatusCode int           // HTTP status code of the response
+	Err        error
+}
+
+//Logger receives structured events from a Client
+type Logger interface {
+	Log(ctx context.Context, event LogEvent)
+}
+
+//LoggerFunc adapts an ordinary function to the Logger interface
+type LoggerFunc func(ctx context.Context, event LogEvent)
+
+//Log calls f(ctx, event)
+func (f LoggerFunc) Log(ctx context.Context, event LogEvent) {
+	f(ctx, event)
+}
+
+//NewStdLogger returns a Logger writing key=value formatted events to a standard library logger
+func NewStdLogger(l *log.Logger) Logger {
+	return LoggerFunc(func(ctx context.Context, e LogEvent) {
+		l.Printf("event=%s method=%s attempt=%d duration=%v wait=%v status=%d url=%q err=%v",
+			e.Kind, e.Method, e.Attempt, e.Duration, e.Wait, e.StatusCode, e.URL, e.Err)
+	})
+}
+
+func (c Client) log(ctx context.Context, event LogEvent) {
+	if c.Logger != nil {
+		c.Logger.Log(ctx, event)
+	}
+}
+
+//redactURL returns the URL as a string with the API key replaced
+func redactURL(u *url.URL) string {
+	redacted := *u
+	q := redacted.Query()
+	if _, ok := q["key"]; ok {
+		q.Set("key", "REDACTED")
+		redacted.RawQuery = q.Encode()
+	}
+	return redacted.String()
+}
+
+//redactError replaces the API key in the URL carried by an HTTP transport error,
+//which would otherwise appear in its message
+func redactError(err error) error {
+	urlErr, ok := err.(*url.Error)
+	if !ok {
+		return err
+	}
+	redacted := *urlErr
+	if u, perr := url.Parse(urlErr.URL); perr == nil {
+		redacted.URL = redactURL(u)
+	} else {
+		redacted.URL = "REDACTED"
+	}
+	return &redacted
+}
@@ -1,6 +1,7 @@
 package petfinder
 
 import (
+	"bytes"
 	"encoding/json"
 	"time"
 )
@@ -16,84 +17,99 @@ type petIDResponse struct {
 	} `json:"petfinder"`
 }
 
+//petID is the ID returned by pet.getRandom with id output
+type petID string
+
+//UnmarshalJSON is a custom unmarshaller for petID
+func (id *petID) UnmarshalJSON(buf []byte) error {
+	var petIDResp petIDResponse
+	err := json.Unmarshal(buf, &petIDResp)
+	if err != nil {
+		return err
+	}
+
+	*id = petID(petIDResp.Petfinder.PetIds.ID.T)
+	return nil
+}
+
 type petSingle struct {
 	Options struct {
-		Option interface{} `json:"option"`
-	} `json:"options"`
+		Option textList `json:"option" xml:"option"`
+	} `json:"options" xml:"options"`
 	Status struct {
-		T string `json:"$t"`
-	} `json:"status"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"status" xml:"status"`
 	Contact struct {
 		Phone struct {
-			T string `json:"$t"`
-		} `json:"phone"`
+			T string `json:"$t" xml:",chardata"`
+		} `json:"phone" xml:"phone"`
 		State struct {
-			T string `json:"$t"`
-		} `json:"state"`
+			T string `json:"$t" xml:",chardata"`
+		} `json:"state" xml:"state"`
 		Address2 struct {
-			T string `json:"$t"`
-		} `json:"address2"`
+			T string `json:"$t" xml:",chardata"`
+		} `json:"address2" xml:"address2"`
 		Email struct {
-			T string `json:"$t"`
-		} `json:"email"`
+			T string `json:"$t" xml:",chardata"`
+		} `json:"email" xml:"email"`
 		City struct {
-			T string `json:"$t"`
-		} `json:"city"`
+			T string `json:"$t" xml:",chardata"`
+		} `json:"city" xml:"city"`
 		Zip struct {
-			T string `json:"$t"`
-		} `json:"zip"`
+			T string `json:"$t" xml:",chardata"`
+		} `json:"zip" xml:"zip"`
 		Fax struct {
-			T string `json:"$t"`
-		} `json:"fax"`
+			T string `json:"$t" xml:",chardata"`
+		} `json:"fax" xml:"fax"`
 		Address1 struct {
-			T string `json:"$t"`
-		} `json:"address1"`
-	} `json:"contact"`
+			T string `json:"$t" xml:",chardata"`
+		} `json:"address1" xml:"address1"`
+	} `json:"contact" xml:"contact"`
 	Age struct {
-		T string `json:"$t"`
-	} `json:"age"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"age" xml:"age"`
 	Size struct {
-		T string `json:"$t"`
-	} `json:"size"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"size" xml:"size"`
 	Media struct {
 		Photos struct {
 			Photo []struct {
-				Size string `json:"@size"`
-				T    string `json:"$t"`
-				ID   string `json:"@id"`
-			} `json:"photo"`
-		} `json:"photos"`
-	} `json:"media"`
+				Size string `json:"@size" xml:"size,attr"`
+				T    string `json:"$t" xml:",chardata"`
+				ID   string `json:"@id" xml:"id,attr"`
+			} `json:"photo" xml:"photo"`
+		} `json:"photos" xml:"photos"`
+	} `json:"media" xml:"media"`
 	ID struct {
-		T string `json:"$t"`
-	} `json:"id"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"id" xml:"id"`
 	ShelterPetID struct {
-		T string `json:"$t"`
-	} `json:"shelterPetId"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"shelterPetId" xml:"shelterPetId"`
 	Breeds struct {
-		Breed interface{} `json:"breed"`
-	} `json:"breeds"`
+		Breed textList `json:"breed" xml:"breed"`
+	} `json:"breeds" xml:"breeds"`
 	Name struct {
-		T string `json:"$t"`
-	} `json:"name"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"name" xml:"name"`
 	Sex struct {
-		T string `json:"$t"`
-	} `json:"sex"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"sex" xml:"sex"`
 	Description struct {
-		T string `json:"$t"`
-	} `json:"description"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"description" xml:"description"`
 	Mix struct {
-		T string `json:"$t"`
-	} `json:"mix"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"mix" xml:"mix"`
 	ShelterID struct {
-		T string `json:"$t"`
-	} `json:"shelterId"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"shelterId" xml:"shelterId"`
 	LastUpdate struct {
-		T time.Time `json:"$t"`
-	} `json:"lastUpdate"`
+		T time.Time `json:"$t" xml:",chardata"`
+	} `json:"lastUpdate" xml:"lastUpdate"`
 	Animal struct {
-		T string `json:"$t"`
-	} `json:"animal"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"animal" xml:"animal"`
 }
 
 type petResponse struct {
@@ -106,22 +122,10 @@ type petResponse struct {
 type petFindResponse struct {
 	Petfinder struct {
 		LastOffset struct {
-			T string `json:"lastOffset"`
-		}
-		Pets struct {
-			Pet petSingle `json:"pet"`
-		} `json:"pets"`
-		Header header `json:"header"`
-	} `json:"petfinder"`
-}
-
-type petFindResponses struct {
-	Petfinder struct {
-		LastOffset struct {
-			T string `json:"lastOffset"`
-		}
+			T string `json:"$t"`
+		} `json:"lastOffset"`
 		Pets struct {
-			Pet []petSingle `json:"pet"`
+			Pet json.RawMessage `json:"pet"`
 		} `json:"pets"`
 		Header header `json:"header"`
 	} `json:"petfinder"`
@@ -129,50 +133,35 @@ type petFindResponses struct {
 
 //Pet contains all the information about a single pet
 type Pet struct {
-	Status  string
-	Options []string
-	Contact struct {
-		Address1 string
-		Address2 string
-		City     string
-		Zip      string
-		State    string
-		Phone    string
-		Email    string
-		Fax      string
-	}
-	Age   string
-	Size  string
-	Media struct {
-		Photos []struct {
-			Size string
-			URL  string
-			ID   string
-		}
+	Status     PetStatus
+	Options    []string
+	Attributes Attributes // parsed from Options
+	Contact    Contact
+	Age        Age
+	Size       Size
+	Media      struct {
+		Photos []Photo
 	}
 	ID           string
 	ShelterPetID string
 	Breeds       []string
 	Name         string
-	Sex          string
+	Sex          Sex
 	Description  string
 	Mix          string
 	ShelterID    string
 	LastUpdate   time.Time
-	Animal       string
+	Animal       Animal
+
+	//Distance in kilometers to the pet's shelter, nil unless set by Pets.AttachDistances
+	Distance *float64
 }
 
 func (p *Pet) mapPetResponse(petR petSingle) {
-	switch option := petR.Options.Option.(type) {
-	case []interface{}:
-		for _, o := range option {
-			p.Options = append(p.Options, o.(map[string]interface{})["$t"].(string))
-		}
-	case interface{}:
-		p.Options = []string{option.(map[string]interface{})["$t"].(string)}
-	}
+	p.Options = []string(petR.Options.Option)
+	p.Attributes = ParseAttributes(p.Options)
 
-	p.Status = petR.Status.T
+	p.Status = PetStatus(normalizeEnum(petStatusEntries, petR.Status.T))
 
 	p.Contact.Phone = petR.Contact.Phone.T
 	p.Contact.State = petR.Contact.State.T
@@ -183,37 +172,25 @@ func (p *Pet) mapPetResponse(petR petSingle) {
 	p.Contact.Zip = petR.Contact.Zip.T
 	p.Contact.Fax = petR.Contact.Fax.T
 
-	p.Age = petR.Age.T
-	p.Size = petR.Size.T
+	p.Age = Age(normalizeEnum(ageEntries, petR.Age.T))
+	p.Size = Size(normalizeEnum(sizeEntries, petR.Size.T))
 
 	p.ID = petR.ID.T
 	p.ShelterPetID = petR.ShelterPetID.T
 
-	switch breed := petR.Breeds.Breed.(type) {
-	case []interface{}:
-		for _, b := range breed {
-			p.Breeds = append(p.Breeds, b.(map[string]interface{})["$t"].(string))
-		}
-	case interface{}:
-		p.Breeds = []string{breed.(map[string]interface{})["$t"].(string)}
-	}
+	p.Breeds = []string(petR.Breeds.Breed)
 
 	for _, photo := range petR.Media.Photos.Photo {
-		photoStruct := struct {
-			Size string
-			URL  string
-			ID   string
-		}{Size: photo.Size, URL: photo.T, ID: photo.ID}
-		p.Media.Photos = append(p.Media.Photos, photoStruct)
+		p.Media.Photos = append(p.Media.Photos, Photo{Size: PhotoSize(photo.Size), URL: photo.T, ID: photo.ID})
 	}
 
 	p.Name = petR.Name.T
-	p.Sex = petR.Sex.T
+	p.Sex = Sex(normalizeEnum(sexEntries, petR.Sex.T))
 	p.Description = petR.Description.T
 	p.Mix = petR.Mix.T
 	p.ShelterID = petR.ShelterID.T
 	p.LastUpdate = petR.LastUpdate.T
-	p.Animal = petR.Animal.T
+	p.Animal = Animal(normalizeEnum(animalEntries, petR.Animal.T))
 }
 
 //UnmarshalJSON is a custom unmarshaller for Pet
@@ -233,27 +210,47 @@ type Pets []Pet
 
 //UnmarshalJSON is a custom unmarshaller for Pets
 func (p *Pets) UnmarshalJSON(buf []byte) error {
-	var pet Pet
+	pets, _, err := decodePetFind(buf)
+	if err != nil {
+		return err
+	}
+	*p = append(*p, pets...)
+	return nil
+}
+
+//decodePetFind decodes a list of pets along with the lastOffset reported by the API.
+//The API returns a single object rather than an array when only one pet matches.
+func decodePetFind(buf []byte) (Pets, int, error) {
 	var petFindResp petFindResponse
 	err := json.Unmarshal(buf, &petFindResp)
 	if err != nil {
-		var petFindResps petFindResponses
-		err = json.Unmarshal(buf, &petFindResps)
-		if err != nil {
-			return err
-		}
+		return nil, 0, err
+	}
 
-		for _, petR := range petFindResps.Petfinder.Pets.Pet {
-			pet = Pet{}
-			pet.mapPetResponse(petR)
-			*p = append(*p, pet)
-		}
+	lastOffset, err := parseOffset(petFindResp.Petfinder.LastOffset.T)
+	if err != nil {
+		return nil, 0, err
+	}
 
-		return nil
+	var petRs []petSingle
+	raw := bytes.TrimSpace(petFindResp.Petfinder.Pets.Pet)
+	switch {
+	case len(raw) == 0 || bytes.Equal(raw, []byte("null")):
+	case raw[0] == '[':
+		err = json.Unmarshal(raw, &petRs)
+	default:
+		petRs = make([]petSingle, 1)
+		err = json.Unmarshal(raw, &petRs[0])
+	}
+	if err != nil {
+		return nil, 0, err
 	}
 
-	pet = Pet{}
-	pet.mapPetResponse(petFindResp.Petfinder.Pets.Pet)
-	*p = append(*p, pet)
-	return nil
+	pets := make(Pets, 0, len(petRs))
+	for _, petR := range petRs {
+		pet := Pet{}
+		pet.mapPetResponse(petR)
+		pets = append(pets, pet)
+	}
+	return pets, lastOffset, nil
 }
@@ -0,0 +1,50 @@
+package petfinder
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestPetUnmarshalOptionShapes(t *testing.T) {
+	tests := []struct {
+		options string
+		want    []string
+		wantErr bool
+	}{
+		{`{"option":[{"$t":"altered"},{"$t":"hasShots"}]}`, []string{"altered", "hasShots"}, false},
+		{`{"option":{"$t":"altered"}}`, []string{"altered"}, false},
+		{`{"option":null}`, nil, false},
+		{`{"option":{}}`, nil, false},
+		{`{"option":[{}, null, {"$t":null}]}`, nil, false},
+		{`{}`, nil, false},
+		{`{"option":{"$t":5}}`, []string{"5"}, false},
+		{`{"option":{"$t":true}}`, []string{"true"}, false},
+		{`{"option":"altered"}`, nil, true},
+		{`{"option":{"$t":{"nested":1}}}`, nil, true},
+		{`{"option":[["altered"]]}`, nil, true},
+	}
+
+	for _, tt := range tests {
+		body := fmt.Sprintf(`{"petfinder":{"pet":{"options":%s,"breeds":{"breed":{"$t":"Beagle"}}}}}`, tt.options)
+		var pet Pet
+		err := json.Unmarshal([]byte(body), &pet)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected decode error", tt.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.options, err)
+			continue
+		}
+		if !reflect.DeepEqual(pet.Options, tt.want) {
+			t.Errorf("%s: expected options %v, got %v", tt.options, tt.want, pet.Options)
+		}
+		if !reflect.DeepEqual(pet.Breeds, []string{"Beagle"}) {
+			t.Errorf("%s: unexpected breeds %v", tt.options, pet.Breeds)
+		}
+	}
+}
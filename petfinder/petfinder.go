@@ -1,331 +1,396 @@
 package petfinder
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"io/ioutil"
-	"log"
-	"math"
 	"net/http"
 	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
-const (
-	retryMax = 4
-	minWait  = 600 * time.Millisecond
-	maxWait  = 5 * time.Second
-)
-
 //Client is the Petfinder API client entrypoint
 type Client struct {
-	apiKey     string
-	baseURL    string
-	format     string
-	HTTPClient *http.Client
+	apiKey        string
+	baseURL       string
+	format        Format
+	userAgent     string
+	timeout       time.Duration
+	allowInsecure bool
+	cache         *responseCache
+	HTTPClient    *http.Client
+
+	RetryPolicy RetryPolicy
+	RateLimiter *RateLimiter // optional, shared limiter applied to every attempt including retries
+	Logger      Logger       // optional, receives structured request events
 }
 
-//NewClient creates a new Petfinder API client as an entrypoint with a given api key
-func NewClient(apiKey string) Client {
+//NewClient creates a new Petfinder API client as an entrypoint with a given api key.
+//Requests are sent over HTTPS to api.petfinder.com unless configured otherwise by the options.
+func NewClient(apiKey string, opts ...ClientOption) Client {
 	p := Client{
 		apiKey:     apiKey,
-		baseURL:    "http://api.petfinder.com/",
-		format:     "json",
+		baseURL:    "https://api.petfinder.com/",
+		format:     JSON,
+		userAgent:  defaultUserAgent,
 		HTTPClient: &http.Client{},
+
+		RetryPolicy: DefaultRetryPolicy(),
+	}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	if p.timeout > 0 {
+		httpClient := *p.HTTPClient
+		httpClient.Timeout = p.timeout
+		p.HTTPClient = &httpClient
 	}
 	return p
 }
 
 //Options are input arguments to the Petfind API
 type Options struct {
-	ID          string `url:"id"`
-	Animal      string `url:"animal"`
-	Breed       string `url:"breed"`
-	Size        string `url:"size"`
-	Sex         string `url:"sex"`
-	Location    string `url:"location"`
-	Age         string `url:"age"`
-	Offset      int    `url:"offset"`
-	Count       int    `url:"count"`
-	Output      string `url:"output"`
-	ShelterID   string `url:"shelterid"`
-	ShelterName string `url:"name"`
-	Status      string `url:"status"`
+	ID          string      `url:"id"`
+	Animal      Animal      `url:"animal"`
+	Breed       string      `url:"breed"`
+	Size        Size        `url:"size"`
+	Sex         Sex         `url:"sex"`
+	Location    string      `url:"location"`
+	Age         Age         `url:"age"`
+	Offset      int         `url:"offset"`
+	Count       int         `url:"count"`
+	Output      OutputLevel `url:"output"`
+	ShelterID   string      `url:"shelterid"`
+	ShelterName string      `url:"name"`
+	Status      PetStatus   `url:"status"`
 }
 
-func (o Options) validate() error {
-	validAnimals := map[string]struct{}{
-		"barnyard":   struct{}{},
-		"bird":       struct{}{},
-		"cat":        struct{}{},
-		"dog":        struct{}{},
-		"horse":      struct{}{},
-		"reptile":    struct{}{},
-		"smallfurry": struct{}{},
-	}
-	if o.Animal != "" {
-		if _, ok := validAnimals[o.Animal]; !ok {
-			return fmt.Errorf("Invalid animal specified")
-		}
-	}
+func (c Client) submitRequest(ctx context.Context, apiMethod string, opt Options) ([]byte, error) {
+	var body []byte
 
-	validSizes := map[string]struct{}{
-		"S":  struct{}{},
-		"M":  struct{}{},
-		"L":  struct{}{},
-		"XL": struct{}{},
-	}
-	if o.Size != "" {
-		if _, ok := validSizes[o.Size]; !ok {
-			return fmt.Errorf("Invalid size specified")
-		}
+	endpoint := c.baseURL + apiMethod
+	request, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return body, err
 	}
-
-	validSex := map[string]struct{}{
-		"M": struct{}{},
-		"F": struct{}{},
+	if request.URL.Scheme != "https" && !c.allowInsecure {
+		return body, fmt.Errorf("Refusing to send API key over %s, use WithInsecureHTTP to allow it", request.URL.Scheme)
 	}
-	if o.Sex != "" {
-		if _, ok := validSex[o.Sex]; !ok {
-			return fmt.Errorf("Invalid sex specified")
-		}
+	if c.userAgent != "" {
+		request.Header.Set("User-Agent", c.userAgent)
 	}
 
-	validAges := map[string]struct{}{
-		"Baby":   struct{}{},
-		"Young":  struct{}{},
-		"Adult":  struct{}{},
-		"Senior": struct{}{},
-	}
-	if o.Age != "" {
-		if _, ok := validAges[o.Age]; !ok {
-			return fmt.Errorf("Invalid age specified")
-		}
+	err = opt.validate(apiMethod)
+	if err != nil {
+		return body, err
 	}
 
-	validOutputs := map[string]struct{}{
-		"basic": struct{}{},
-		"full":  struct{}{},
-		"id":    struct{}{},
+	q, err := query.Values(opt)
+	if err != nil {
+		return body, err
 	}
-	if o.Output != "" {
-		if _, ok := validOutputs[o.Output]; !ok {
-			return fmt.Errorf("Invalid output specified")
+
+	q["format"] = []string{string(c.format)}
+
+	// serve from the cache, keyed by endpoint without the API key
+	cacheTTL := c.cache.ttl(apiMethod)
+	cacheKey := endpoint + "?" + q.Encode()
+	if cacheTTL > 0 {
+		if cached, ok := c.cache.get(cacheKey); ok {
+			return cached, nil
 		}
 	}
 
-	validStatuses := map[string]struct{}{
-		"A": struct{}{},
-		"H": struct{}{},
-		"P": struct{}{},
-		"X": struct{}{},
-	}
-	if o.Status != "" {
-		if _, ok := validStatuses[o.Status]; !ok {
-			return fmt.Errorf("Invalid status specified")
+	q["key"] = []string{c.apiKey}
+	request.URL.RawQuery = q.Encode()
+
+	// submit request with retries
+	redacted := redactURL(request.URL)
+	var cacheable bool
+	err = c.RetryPolicy.do(ctx, apiMethod, func(i int) (bool, time.Duration, error) {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx); err != nil {
+				return false, 0, err
+			}
 		}
-	}
 
-	return nil
+		c.log(ctx, LogEvent{Kind: EventRequest, Method: apiMethod, URL: redacted, Attempt: i})
+		start := time.Now()
+		result, err := c.attempt(request, apiMethod)
+		body, cacheable = result.body, result.headerOK
+		c.log(ctx, LogEvent{
+			Kind:       EventResponse,
+			Method:     apiMethod,
+			URL:        redacted,
+			Attempt:    i,
+			Duration:   time.Since(start),
+			StatusCode: result.status,
+			Err:        err,
+		})
+		return result.retry, result.retryAfter, err
+	}, func(e RetryEvent) {
+		c.log(ctx, LogEvent{Kind: EventRetry, Method: apiMethod, URL: redacted, Attempt: e.Attempt, Wait: e.Wait, Err: e.Err})
+	})
+
+	// only cache a body whose header decoded and reported success
+	if err == nil && cacheable && cacheTTL > 0 {
+		c.cache.cache.Set(cacheKey, body, cacheTTL)
+	}
+	return body, err
 }
 
-func (c Client) submitRequest(apiMethod string, opt Options) ([]byte, error) {
-	var body []byte
-	var response *http.Response
-	var sleep time.Duration
+//attemptResult is the outcome of submitting a request once
+type attemptResult struct {
+	body       []byte
+	status     int
+	retry      bool          // whether a failed attempt may be retried
+	headerOK   bool          // whether the response header decoded and reported success
+	retryAfter time.Duration // delay requested by the server before retrying
+}
 
-	endpoint := c.baseURL + apiMethod
-	request, err := http.NewRequest("GET", endpoint, nil)
+//attempt submits the request once
+func (c Client) attempt(request *http.Request, apiMethod string) (attemptResult, error) {
+	var result attemptResult
+	response, err := c.HTTPClient.Do(request)
 	if err != nil {
-		return body, err
+		result.retry = request.Context().Err() == nil
+		return result, redactError(err)
 	}
+	defer response.Body.Close()
 
-	err = opt.validate()
+	result.status = response.StatusCode
+	result.body, err = ioutil.ReadAll(response.Body)
 	if err != nil {
-		return body, err
+		result.retry = request.Context().Err() == nil
+		return result, err
 	}
 
-	q, err := query.Values(opt)
-	if err != nil {
-		return body, err
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		result.retryAfter = parseRetryAfter(response.Header.Get("Retry-After"), time.Now())
+		result.retry = c.RetryPolicy.ShouldRetryStatus(response.StatusCode)
+		return result, &HTTPError{StatusCode: response.StatusCode, Method: apiMethod, RetryAfter: result.retryAfter}
 	}
 
-	q["key"] = []string{c.apiKey}
-	q["format"] = []string{c.format}
-	request.URL.RawQuery = q.Encode()
-
-	// submit request with retries
-	for i := 0; ; i++ {
-		response, err = c.HTTPClient.Do(request)
-		if err == nil || i == retryMax {
-			break
-		}
-		sleep = time.Duration(math.Pow(2, float64(i)) * float64(minWait))
-		if sleep > maxWait {
-			sleep = maxWait
+	// surface the status reported in the response header
+	if h, ok := c.format.header(result.body); ok {
+		if err = h.err(apiMethod); err != nil {
+			apiErr, ok := err.(*APIError)
+			result.retry = ok && c.RetryPolicy.retryableCode(apiErr.Code)
+			return result, err
 		}
-		log.Printf("Retrying in %v with %d retries remaining", sleep, retryMax-i-1)
-		time.Sleep(sleep)
+		result.headerOK = true
 	}
 
+	return result, nil
+}
+
+//decode unmarshals a response body into v, logging any failure
+func (c Client) decode(ctx context.Context, apiMethod string, body []byte, v interface{}) error {
+	err := c.format.unmarshal(body, v)
 	if err != nil {
-		return body, err
+		c.log(ctx, LogEvent{Kind: EventDecodeError, Method: apiMethod, Err: err})
 	}
+	return err
+}
 
-	defer response.Body.Close()
+//sleepContext pauses for the given duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
 
-	return ioutil.ReadAll(response.Body)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 //ListBreeds returns a slice of breed names for a specified animal
 //animal must be specified and one of the following:
 //  barnyard, bird, cat, dog, horse, reptile, smallfurry
 func (c Client) ListBreeds(opt Options) (Breeds, error) {
-	var b Breeds
+	return c.ListBreedsContext(context.Background(), opt)
+}
 
-	// Check required options
-	if opt.Animal == "" {
-		return b, fmt.Errorf("Require animal type in options")
-	}
+//ListBreedsContext is ListBreeds with a context controlling cancellation of the request
+func (c Client) ListBreedsContext(ctx context.Context, opt Options) (Breeds, error) {
+	var b Breeds
 
-	body, err := c.submitRequest("breed.list", opt)
+	body, err := c.submitRequest(ctx, "breed.list", opt)
 	if err != nil {
 		return b, err
 	}
-	err = json.Unmarshal(body, &b)
+	err = c.decode(ctx, "breed.list", body, &b)
 	return b, err
 }
 
 //GetRandomPetID return a string id of a random pet
 //output option is overriden to id
 func (c Client) GetRandomPetID(opt Options) (string, error) {
-	var id string
+	return c.GetRandomPetIDContext(context.Background(), opt)
+}
 
+//GetRandomPetIDContext is GetRandomPetID with a context controlling cancellation of the request
+func (c Client) GetRandomPetIDContext(ctx context.Context, opt Options) (string, error) {
 	// Override for id output
-	opt.Output = "id"
+	opt.Output = OutputID
 
-	body, err := c.submitRequest("pet.getRandom", opt)
-	if err != nil {
-		return id, err
-	}
-	var petID petIDResponse
-	err = json.Unmarshal(body, &petID)
+	body, err := c.submitRequest(ctx, "pet.getRandom", opt)
 	if err != nil {
-		return id, err
+		return "", err
 	}
-
-	return petID.Petfinder.PetIds.ID.T, nil
+	var id petID
+	err = c.decode(ctx, "pet.getRandom", body, &id)
+	return string(id), err
 }
 
 //GetRandomPet return a single random Pet
 //
 //available options:
-//  Animal     string  optional  type of animal (barnyard, bird, cat, dog, horse, reptile, smallfurry)
-//  Breed      string  optional  breed of animal (use breeds.list for a list of valid breeds)
-//  Size       string  optional  size of animal (S=small, M=medium, L=large, XL=extra-large)
-//  Sex        string  optional  M=male, F=female
-//  Location   string  optional  the ZIP/postal code or city and state the animal should be located (NOTE: the closest possible animal will be selected)
-//  ShelterID  string  optional  ID of the shelter that posted the pet
-//  Output     string  optional  How much of the pet record to return: basic, full
+//  Animal     Animal       optional  type of animal (barnyard, bird, cat, dog, horse, reptile, smallfurry)
+//  Breed      string       optional  breed of animal (use breeds.list for a list of valid breeds)
+//  Size       Size         optional  size of animal (S=small, M=medium, L=large, XL=extra-large)
+//  Sex        Sex          optional  M=male, F=female
+//  Location   string       optional  the ZIP/postal code or city and state the animal should be located (NOTE: the closest possible animal will be selected)
+//  ShelterID  string       optional  ID of the shelter that posted the pet
+//  Output     OutputLevel  optional  How much of the pet record to return: basic, full
 func (c Client) GetRandomPet(opt Options) (Pet, error) {
+	return c.GetRandomPetContext(context.Background(), opt)
+}
+
+//GetRandomPetContext is GetRandomPet with a context controlling cancellation of the request
+func (c Client) GetRandomPetContext(ctx context.Context, opt Options) (Pet, error) {
 	var pet Pet
 
-	// Override for id output
-	if opt.Output == "id" || opt.Output == "" {
-		return pet, fmt.Errorf("Output must be basic or full")
+	// id output is served by GetRandomPetID
+	if opt.Output != OutputBasic && opt.Output != OutputFull {
+		fieldErr := FieldError{
+			Field:   "Output",
+			Value:   string(opt.Output),
+			Reason:  ReasonInvalid,
+			Allowed: []string{string(OutputBasic), string(OutputFull)},
+		}
+		if opt.Output == "" {
+			fieldErr.Reason = ReasonRequired
+		}
+		return pet, &ValidationError{Method: "pet.getRandom", Fields: []FieldError{fieldErr}}
 	}
 
-	body, err := c.submitRequest("pet.getRandom", opt)
+	body, err := c.submitRequest(ctx, "pet.getRandom", opt)
 	if err != nil {
 		return pet, err
 	}
-	err = json.Unmarshal(body, &pet)
+	err = c.decode(ctx, "pet.getRandom", body, &pet)
 	return pet, err
 }
 
 //GetPet retrieves information about a single Pet given an ID
 //id option must be specified which indicates the pet ID
 func (c Client) GetPet(opt Options) (Pet, error) {
-	var pet Pet
+	return c.GetPetContext(context.Background(), opt)
+}
 
-	// Override for id output
-	if opt.ID == "" {
-		return pet, fmt.Errorf("Must specify pet ID")
-	}
+//GetPetContext is GetPet with a context controlling cancellation of the request
+func (c Client) GetPetContext(ctx context.Context, opt Options) (Pet, error) {
+	var pet Pet
 
-	body, err := c.submitRequest("pet.get", opt)
+	body, err := c.submitRequest(ctx, "pet.get", opt)
 	if err != nil {
 		return pet, err
 	}
-	err = json.Unmarshal(body, &pet)
+	err = c.decode(ctx, "pet.get", body, &pet)
 	return pet, err
 }
 
 //FindPet returns a slice of Pets with their information given a location and other search options
 //location option must be specified with represents a zip code or city/state
 func (c Client) FindPet(opt Options) (Pets, error) {
-	var pets Pets
+	return c.FindPetContext(context.Background(), opt)
+}
 
-	if opt.Location == "" {
-		return pets, fmt.Errorf("Must specify zip code location string")
-	}
+//FindPetContext is FindPet with a context controlling cancellation of the request
+func (c Client) FindPetContext(ctx context.Context, opt Options) (Pets, error) {
+	var pets Pets
 
-	body, err := c.submitRequest("pet.find", opt)
+	body, err := c.submitRequest(ctx, "pet.find", opt)
 	if err != nil {
 		return pets, err
 	}
-	err = json.Unmarshal(body, &pets)
+	err = c.decode(ctx, "pet.find", body, &pets)
 	return pets, err
 }
 
 //FindShelter resturns a slice of Shelter information given a location and other search options
 //location option must be specified with represents a zip code or city/state
 func (c Client) FindShelter(opt Options) (Shelters, error) {
-	var shelters Shelters
+	return c.FindShelterContext(context.Background(), opt)
+}
 
-	if opt.Location == "" {
-		return shelters, fmt.Errorf("Must specify zip code or city state location string")
-	}
+//FindShelterContext is FindShelter with a context controlling cancellation of the request
+func (c Client) FindShelterContext(ctx context.Context, opt Options) (Shelters, error) {
+	var shelters Shelters
 
-	body, err := c.submitRequest("shelter.find", opt)
+	body, err := c.submitRequest(ctx, "shelter.find", opt)
 	if err != nil {
 		return shelters, err
 	}
-	err = json.Unmarshal(body, &shelters)
+	err = c.decode(ctx, "shelter.find", body, &shelters)
 	return shelters, err
 }
 
 //GetShelter retrieves Shelter information given an shelter ID
 //id options must be specified which represents the shelter id
 func (c Client) GetShelter(opt Options) (Shelter, error) {
-	var shelter Shelter
+	return c.GetShelterContext(context.Background(), opt)
+}
 
-	if opt.ID == "" {
-		return shelter, fmt.Errorf("Must specify shelter id")
-	}
+//GetShelterContext is GetShelter with a context controlling cancellation of the request
+func (c Client) GetShelterContext(ctx context.Context, opt Options) (Shelter, error) {
+	var shelter Shelter
 
-	body, err := c.submitRequest("shelter.get", opt)
+	body, err := c.submitRequest(ctx, "shelter.get", opt)
 	if err != nil {
 		return shelter, err
 	}
-	err = json.Unmarshal(body, &shelter)
+	err = c.decode(ctx, "shelter.get", body, &shelter)
 	return shelter, err
 }
 
 //GetShelterPets retrieves a slice of Pet information for a shelter ID
 //id options must be specified which represents the shelter id
 func (c Client) GetShelterPets(opt Options) (Pets, error) {
-	var pets Pets
+	return c.GetShelterPetsContext(context.Background(), opt)
+}
 
-	if opt.ID == "" {
-		return pets, fmt.Errorf("Must specify pets id")
-	}
+//GetShelterPetsContext is GetShelterPets with a context controlling cancellation of the request
+func (c Client) GetShelterPetsContext(ctx context.Context, opt Options) (Pets, error) {
+	var pets Pets
 
-	body, err := c.submitRequest("shelter.getPets", opt)
+	body, err := c.submitRequest(ctx, "shelter.getPets", opt)
 	if err != nil {
 		return pets, err
 	}
-	err = json.Unmarshal(body, &pets)
+	err = c.decode(ctx, "shelter.getPets", body, &pets)
 	return pets, err
 }
+
+//ListSheltersByBreed returns a slice of Shelters that have pets of a given animal and breed.
+//Results are paged with the offset and count options.
+//animal and breed options must be specified
+func (c Client) ListSheltersByBreed(opt Options) (Shelters, error) {
+	return c.ListSheltersByBreedContext(context.Background(), opt)
+}
+
+//ListSheltersByBreedContext is ListSheltersByBreed with a context controlling cancellation of the request
+func (c Client) ListSheltersByBreedContext(ctx context.Context, opt Options) (Shelters, error) {
+	var shelters Shelters
+
+	body, err := c.submitRequest(ctx, "shelter.listByBreed", opt)
+	if err != nil {
+		return shelters, err
+	}
+	err = c.decode(ctx, "shelter.listByBreed", body, &shelters)
+	return shelters, err
+}
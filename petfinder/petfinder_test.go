@@ -18,7 +18,7 @@ func fetchAPIKey() (string, error) {
 func TestNewClient(t *testing.T) {
 	apiKey, err := fetchAPIKey()
 	if err != nil {
-		t.Error(err)
+		t.Skip(err)
 	}
 
 	c := NewClient(apiKey)
@@ -30,7 +30,7 @@ func TestNewClient(t *testing.T) {
 func TestBreedList(t *testing.T) {
 	apiKey, err := fetchAPIKey()
 	if err != nil {
-		t.Error(err)
+		t.Skip(err)
 	}
 
 	c := NewClient(apiKey)
@@ -47,7 +47,7 @@ func TestBreedList(t *testing.T) {
 func TestGetRandomPetID(t *testing.T) {
 	apiKey, err := fetchAPIKey()
 	if err != nil {
-		t.Error(err)
+		t.Skip(err)
 	}
 
 	c := NewClient(apiKey)
@@ -65,7 +65,7 @@ func TestGetRandomPetID(t *testing.T) {
 func TestGetRandomPet(t *testing.T) {
 	apiKey, err := fetchAPIKey()
 	if err != nil {
-		t.Error(err)
+		t.Skip(err)
 	}
 
 	c := NewClient(apiKey)
@@ -90,7 +90,7 @@ func TestGetRandomPet(t *testing.T) {
 func TestGetPet(t *testing.T) {
 	apiKey, err := fetchAPIKey()
 	if err != nil {
-		t.Error(err)
+		t.Skip(err)
 	}
 
 	c := NewClient(apiKey)
@@ -113,7 +113,7 @@ func TestGetPet(t *testing.T) {
 func TestFindPet(t *testing.T) {
 	apiKey, err := fetchAPIKey()
 	if err != nil {
-		t.Error(err)
+		t.Skip(err)
 	}
 
 	c := NewClient(apiKey)
@@ -143,7 +143,7 @@ func TestFindPet(t *testing.T) {
 func TestFindShelter(t *testing.T) {
 	apiKey, err := fetchAPIKey()
 	if err != nil {
-		t.Error(err)
+		t.Skip(err)
 	}
 
 	c := NewClient(apiKey)
@@ -172,7 +172,7 @@ func TestFindShelter(t *testing.T) {
 func TestGetShelter(t *testing.T) {
 	apiKey, err := fetchAPIKey()
 	if err != nil {
-		t.Error(err)
+		t.Skip(err)
 	}
 
 	c := NewClient(apiKey)
@@ -188,7 +188,7 @@ func TestGetShelter(t *testing.T) {
 func TestGetShelterPets(t *testing.T) {
 	apiKey, err := fetchAPIKey()
 	if err != nil {
-		t.Error(err)
+		t.Skip(err)
 	}
 
 	c := NewClient(apiKey)
@@ -0,0 +1,191 @@
+package petfinder
+
+import (
+	"fmt"
+	"sort"
+)
+
+//PhotoSize is Petfinder's code for the size class of a photo
+type PhotoSize string
+
+//PhotoSize values
+const (
+	PhotoSizeLarge     PhotoSize = "x"   // original, no larger than 500x500
+	PhotoSizeMedium    PhotoSize = "pn"  // original, no larger than 300x250
+	PhotoSizeSmall     PhotoSize = "fpm" // 95 pixels wide
+	PhotoSizeThumbnail PhotoSize = "pnt" // 60 pixels wide
+	PhotoSizeTiny      PhotoSize = "t"   // 50 pixels tall
+)
+
+var photoSizeEntries = []enumEntry{
+	{string(PhotoSizeLarge), "Large"},
+	{string(PhotoSizeMedium), "Medium"},
+	{string(PhotoSizeSmall), "Small"},
+	{string(PhotoSizeThumbnail), "Thumbnail"},
+	{string(PhotoSizeTiny), "Tiny"},
+}
+
+//photoWidths are the maximum widths in pixels of each size class
+var photoWidths = map[PhotoSize]int{
+	PhotoSizeLarge:     500,
+	PhotoSizeMedium:    300,
+	PhotoSizeSmall:     95,
+	PhotoSizeThumbnail: 60,
+	PhotoSizeTiny:      50,
+}
+
+//ParsePhotoSize parses a photo size from its API code or label, ignoring case
+func ParsePhotoSize(s string) (PhotoSize, error) {
+	v, ok := lookupEnum(photoSizeEntries, s)
+	if !ok {
+		return "", fmt.Errorf("Invalid photo size %q", s)
+	}
+	return PhotoSize(v), nil
+}
+
+//String returns the API code
+func (v PhotoSize) String() string {
+	return string(v)
+}
+
+//Label returns the human-readable name of the size class
+func (v PhotoSize) Label() string {
+	return enumLabel(photoSizeEntries, string(v))
+}
+
+//Valid reports whether the size class is known
+func (v PhotoSize) Valid() bool {
+	return enumValid(photoSizeEntries, string(v))
+}
+
+//Width returns the maximum width in pixels of the size class, 0 if unknown
+func (v PhotoSize) Width() int {
+	return photoWidths[v]
+}
+
+//MarshalText implements encoding.TextMarshaler
+func (v PhotoSize) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler, accepting anything ParsePhotoSize does
+func (v *PhotoSize) UnmarshalText(text []byte) error {
+	parsed, err := ParsePhotoSize(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+//Photo is a single size of a pet photo. Every size of the same image shares an ID.
+type Photo struct {
+	Size PhotoSize
+	URL  string
+	ID   string
+}
+
+//PhotoSet is every size of a single pet photo
+type PhotoSet struct {
+	ID     string
+	Photos []Photo // largest first
+}
+
+//Get returns the photo of the given size
+func (s PhotoSet) Get(size PhotoSize) (Photo, bool) {
+	for _, photo := range s.Photos {
+		if photo.Size == size {
+			return photo, true
+		}
+	}
+	return Photo{}, false
+}
+
+//Largest returns the largest size of the photo
+func (s PhotoSet) Largest() (Photo, bool) {
+	if len(s.Photos) == 0 {
+		return Photo{}, false
+	}
+	return s.Photos[0], true
+}
+
+//Best returns the largest size no wider than maxWidth pixels,
+//or the smallest size if none is narrow enough
+func (s PhotoSet) Best(maxWidth int) (Photo, bool) {
+	if len(s.Photos) == 0 {
+		return Photo{}, false
+	}
+	for _, photo := range s.Photos {
+		if photo.Size.Width() <= maxWidth {
+			return photo, true
+		}
+	}
+	return s.Photos[len(s.Photos)-1], true
+}
+
+//Photos returns every photo of the pet in the given size class
+func (p Pet) Photos(size PhotoSize) []Photo {
+	var photos []Photo
+	for _, photo := range p.Media.Photos {
+		if photo.Size == size {
+			photos = append(photos, photo)
+		}
+	}
+	return photos
+}
+
+//PhotoSets groups the photos of the pet by photo ID, in the order the API listed them
+func (p Pet) PhotoSets() []PhotoSet {
+	var sets []PhotoSet
+	index := make(map[string]int)
+	for _, photo := range p.Media.Photos {
+		i, ok := index[photo.ID]
+		if !ok {
+			i = len(sets)
+			index[photo.ID] = i
+			sets = append(sets, PhotoSet{ID: photo.ID})
+		}
+		sets[i].Photos = append(sets[i].Photos, photo)
+	}
+
+	for _, set := range sets {
+		sort.SliceStable(set.Photos, func(i, j int) bool {
+			return set.Photos[i].Size.Width() > set.Photos[j].Size.Width()
+		})
+	}
+	return sets
+}
+
+//primaryPhotoSet returns the photo with ID 1, which shelters choose as the main photo,
+//falling back to the first photo listed
+func (p Pet) primaryPhotoSet() (PhotoSet, bool) {
+	sets := p.PhotoSets()
+	for _, set := range sets {
+		if set.ID == "1" {
+			return set, true
+		}
+	}
+	if len(sets) == 0 {
+		return PhotoSet{}, false
+	}
+	return sets[0], true
+}
+
+//PrimaryPhoto returns the largest size of the pet's main photo
+func (p Pet) PrimaryPhoto() (Photo, bool) {
+	set, ok := p.primaryPhotoSet()
+	if !ok {
+		return Photo{}, false
+	}
+	return set.Largest()
+}
+
+//BestPhoto returns the largest size of the pet's main photo no wider than maxWidth pixels,
+//or its smallest size if none is narrow enough
+func (p Pet) BestPhoto(maxWidth int) (Photo, bool) {
+	set, ok := p.primaryPhotoSet()
+	if !ok {
+		return Photo{}, false
+	}
+	return set.Best(maxWidth)
+}
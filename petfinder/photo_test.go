@@ -0,0 +1,47 @@
+package petfinder
+
+import (
+	"testing"
+)
+
+func TestPetPhotos(t *testing.T) {
+	c, _ := newFixtureClient(t)
+	pet, err := c.GetPet(Options{ID: "39930101"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if thumbs := pet.Photos(PhotoSizeThumbnail); len(thumbs) != 2 || thumbs[1].ID != "2" {
+		t.Errorf("Unexpected thumbnails %+v", thumbs)
+	}
+
+	sets := pet.PhotoSets()
+	if len(sets) != 2 || sets[0].ID != "1" || len(sets[0].Photos) != 5 {
+		t.Fatalf("Unexpected photo sets %+v", sets)
+	}
+	if sets[1].Photos[0].Size != PhotoSizeLarge || sets[1].Photos[4].Size != PhotoSizeTiny {
+		t.Errorf("Expected photos largest first, got %+v", sets[1].Photos)
+	}
+
+	if photo, ok := pet.PrimaryPhoto(); !ok || photo.ID != "1" || photo.Size != PhotoSizeLarge {
+		t.Errorf("Unexpected primary photo %+v", photo)
+	}
+	tests := []struct {
+		maxWidth int
+		want     PhotoSize
+	}{
+		{1000, PhotoSizeLarge},
+		{320, PhotoSizeMedium},
+		{100, PhotoSizeSmall},
+		{10, PhotoSizeTiny},
+	}
+	for _, tt := range tests {
+		if photo, _ := pet.BestPhoto(tt.maxWidth); photo.Size != tt.want {
+			t.Errorf("BestPhoto(%d) = %s, expected %s", tt.maxWidth, photo.Size, tt.want)
+		}
+	}
+
+	if _, ok := (Pet{}).PrimaryPhoto(); ok {
+		t.Errorf("Expected no primary photo for a pet without photos")
+	}
+}
@@ -0,0 +1,397 @@
+//Package photos mirrors pet photos from the Petfinder API into a local directory
+//so they remain available offline.
+//
+//Photos are stored as <dir>/<pet ID>/<photo ID>-<size><ext> and described by a
+//manifest.json in the same directory. Downloads already present with a matching
+//content hash are skipped, and a recorded ETag is sent with If-None-Match so an
+//unchanged photo is not downloaded again when its URL changes.
+package photos
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aouyang1/go-petfinder/petfinder"
+)
+
+//ManifestFile is the name of the manifest written to the download directory
+const ManifestFile = "manifest.json"
+
+//defaultWorkers is the number of concurrent downloads unless configured
+const defaultWorkers = 4
+
+//Manifest describes every photo stored in a download directory
+type Manifest struct {
+	Pets map[string][]File `json:"pets"` // keyed by Pet.ID
+}
+
+//File is a photo stored locally
+type File struct {
+	PhotoID string              `json:"photo_id"`
+	Size    petfinder.PhotoSize `json:"size"`
+	URL     string              `json:"url"`
+	Path    string              `json:"path"` // relative to the download directory, slash separated
+	SHA256  string              `json:"sha256"`
+	ETag    string              `json:"etag,omitempty"`
+}
+
+//Downloader mirrors pet photos into a directory
+type Downloader struct {
+	Client  petfinder.Client      // HTTPClient and RetryPolicy are used for every download, honouring Retry-After
+	Dir     string                // download directory, created if missing
+	Sizes   []petfinder.PhotoSize // size classes to download, defaults to PhotoSizeLarge
+	Workers int                   // number of concurrent downloads, defaults to 4
+}
+
+//NewDownloader returns a downloader storing the largest size of every photo in dir
+func NewDownloader(c petfinder.Client, dir string) *Downloader {
+	return &Downloader{
+		Client:  c,
+		Dir:     dir,
+		Sizes:   []petfinder.PhotoSize{petfinder.PhotoSizeLarge},
+		Workers: defaultWorkers,
+	}
+}
+
+//Result holds the outcome of Download
+type Result struct {
+	Manifest   Manifest
+	Downloaded int              // photos written to disk
+	Skipped    int              // photos already present and unchanged
+	Errors     map[string]error // photos that could not be downloaded, keyed by URL
+}
+
+//job is a single photo to download
+type job struct {
+	petID string
+	photo petfinder.Photo
+	prev  *File // record from an earlier run, if any
+}
+
+//Download stores the configured sizes of every photo of the pets and writes the manifest.
+//Photos recorded by an earlier run into the same directory are kept in the manifest.
+//Photos that fail to download are listed in Result.Errors and left out of the manifest.
+//The returned error is set when the manifest could not be read or written
+//or the context ended before every photo was downloaded.
+func (d *Downloader) Download(ctx context.Context, pets petfinder.Pets) (Result, error) {
+	result := Result{Errors: make(map[string]error)}
+
+	if err := os.MkdirAll(d.Dir, 0755); err != nil {
+		return result, err
+	}
+	manifest, err := ReadManifest(d.Dir)
+	if err != nil {
+		return result, err
+	}
+
+	jobs := d.jobs(pets, manifest)
+	files := make([]*File, len(jobs))
+	skipped := make([]bool, len(jobs))
+	errs := make([]error, len(jobs))
+
+	workers := d.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				files[i], skipped[i], errs[i] = d.fetch(ctx, jobs[i])
+			}
+		}()
+	}
+
+feed:
+	for i := range jobs {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			for j := i; j < len(jobs); j++ {
+				errs[j] = ctx.Err()
+			}
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i, j := range jobs {
+		key := fileKey(j.photo.ID, j.photo.Size)
+		if errs[i] != nil {
+			result.Errors[j.photo.URL] = errs[i]
+			continue
+		}
+		if skipped[i] {
+			result.Skipped++
+		} else {
+			result.Downloaded++
+		}
+
+		// replace any earlier record of the same photo
+		records := manifest.Pets[j.petID]
+		replaced := false
+		for k := range records {
+			if fileKey(records[k].PhotoID, records[k].Size) == key {
+				records[k] = *files[i]
+				replaced = true
+			}
+		}
+		if !replaced {
+			records = append(records, *files[i])
+		}
+		manifest.Pets[j.petID] = records
+	}
+	for _, records := range manifest.Pets {
+		sort.SliceStable(records, func(a, b int) bool {
+			return records[a].Path < records[b].Path
+		})
+	}
+
+	result.Manifest = manifest
+	if err = writeManifest(d.Dir, manifest); err != nil {
+		return result, err
+	}
+	return result, ctx.Err()
+}
+
+//jobs lists the photos of the pets in the configured sizes
+func (d *Downloader) jobs(pets petfinder.Pets, manifest Manifest) []job {
+	sizes := d.Sizes
+	if len(sizes) == 0 {
+		sizes = []petfinder.PhotoSize{petfinder.PhotoSizeLarge}
+	}
+
+	var jobs []job
+	for _, pet := range pets {
+		prev := make(map[string]*File)
+		records := manifest.Pets[pet.ID]
+		for i := range records {
+			prev[fileKey(records[i].PhotoID, records[i].Size)] = &records[i]
+		}
+		for _, size := range sizes {
+			for _, photo := range pet.Photos(size) {
+				jobs = append(jobs, job{petID: pet.ID, photo: photo, prev: prev[fileKey(photo.ID, photo.Size)]})
+			}
+		}
+	}
+	return jobs
+}
+
+//fetch downloads a single photo unless an identical copy is already stored
+func (d *Downloader) fetch(ctx context.Context, j job) (*File, bool, error) {
+	file := &File{
+		PhotoID: j.photo.ID,
+		Size:    j.photo.Size,
+		URL:     j.photo.URL,
+		Path:    path.Join(safeName(j.petID), safeName(j.photo.ID)+"-"+safeName(string(j.photo.Size))+photoExt(j.photo.URL)),
+	}
+	local := filepath.Join(d.Dir, filepath.FromSlash(file.Path))
+
+	// a verified copy of the same URL needs no request at all
+	existing := ""
+	if j.prev != nil && j.prev.Path == file.Path {
+		if sum, err := hashFile(local); err == nil && sum == j.prev.SHA256 {
+			existing = sum
+			if j.prev.URL == file.URL {
+				return j.prev, true, nil
+			}
+		}
+	}
+
+	etag := ""
+	if existing != "" {
+		etag = j.prev.ETag
+	}
+	body, newETag, err := d.get(ctx, file.URL, etag)
+	if err != nil {
+		return nil, false, err
+	}
+	if body == nil {
+		// not modified since the recorded ETag
+		file.SHA256 = existing
+		file.ETag = etag
+		return file, true, nil
+	}
+
+	sum := sha256.Sum256(body)
+	file.SHA256 = hex.EncodeToString(sum[:])
+	file.ETag = newETag
+	if file.SHA256 == existing {
+		return file, true, nil
+	}
+	if err = writeFile(local, body); err != nil {
+		return nil, false, err
+	}
+	return file, false, nil
+}
+
+//get downloads a URL under the client's retry policy.
+//A nil body is returned when the server reports the ETag is still current.
+func (d *Downloader) get(ctx context.Context, rawurl, etag string) ([]byte, string, error) {
+	httpClient := d.Client.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	policy := d.Client.RetryPolicy
+
+	var body []byte
+	var newETag string
+	err := policy.Do(ctx, "photo", func(int) (bool, time.Duration, error) {
+		var retry bool
+		var retryAfter time.Duration
+		var err error
+		body, newETag, retry, retryAfter, err = attempt(ctx, httpClient, policy, rawurl, etag)
+		return retry, retryAfter, err
+	})
+	return body, newETag, err
+}
+
+//attempt downloads a URL once, reporting whether a failure may be retried and after what delay
+func attempt(ctx context.Context, httpClient *http.Client, policy petfinder.RetryPolicy, rawurl, etag string) ([]byte, string, bool, time.Duration, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, rawurl, nil)
+	if err != nil {
+		return nil, "", false, 0, err
+	}
+	if etag != "" {
+		request.Header.Set("If-None-Match", etag)
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, "", ctx.Err() == nil, 0, err
+	}
+	defer response.Body.Close()
+
+	if etag != "" && response.StatusCode == http.StatusNotModified {
+		return nil, etag, false, 0, nil
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		io.Copy(ioutil.Discard, response.Body)
+		err = fmt.Errorf("Photo download %s failed with HTTP status %d", rawurl, response.StatusCode)
+		retryAfter := petfinder.ParseRetryAfter(response.Header.Get("Retry-After"))
+		return nil, "", policy.ShouldRetryStatus(response.StatusCode), retryAfter, err
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, "", ctx.Err() == nil, 0, err
+	}
+	return body, response.Header.Get("ETag"), false, 0, nil
+}
+
+//ReadManifest reads the manifest of a download directory.
+//An empty manifest is returned if the directory has none yet.
+func ReadManifest(dir string) (Manifest, error) {
+	manifest := Manifest{Pets: make(map[string][]File)}
+	buf, err := ioutil.ReadFile(filepath.Join(dir, ManifestFile))
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return manifest, err
+	}
+	if err = json.Unmarshal(buf, &manifest); err != nil {
+		return manifest, fmt.Errorf("Invalid photo manifest: %v", err)
+	}
+	if manifest.Pets == nil {
+		manifest.Pets = make(map[string][]File)
+	}
+	return manifest, nil
+}
+
+func writeManifest(dir string, manifest Manifest) error {
+	buf, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(dir, ManifestFile), buf)
+}
+
+//writeFile replaces a file atomically so an interrupted run never leaves a partial photo
+func writeFile(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(name), ".download-*")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func hashFile(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func fileKey(photoID string, size petfinder.PhotoSize) string {
+	return photoID + "-" + string(size)
+}
+
+//photoExt returns the file extension of a photo URL, defaulting to .jpg
+func photoExt(rawurl string) string {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return ".jpg"
+	}
+	ext := strings.ToLower(path.Ext(u.Path))
+	switch ext {
+	case ".jpg", ".jpeg", ".png", ".gif", ".webp":
+		return ext
+	}
+	return ".jpg"
+}
+
+//safeName keeps API supplied IDs from escaping the download directory
+func safeName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r < ' ' {
+			return '_'
+		}
+		return r
+	}, s)
+	if s == "" || s == "." || s == ".." {
+		return "_"
+	}
+	return s
+}
@@ -0,0 +1,130 @@
+package photos
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aouyang1/go-petfinder/petfinder"
+)
+
+func TestDownload(t *testing.T) {
+	var mu sync.Mutex
+	hits := make(map[string]int)
+	failures := 1
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		hits[r.URL.Path]++
+
+		if r.URL.Path == "/flaky.jpg" && failures > 0 {
+			failures--
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path == "/missing.jpg" {
+			http.NotFound(w, r)
+			return
+		}
+		etag := `"` + r.URL.Path + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		fmt.Fprint(w, "image ", r.URL.Path)
+	}))
+	defer ts.Close()
+
+	c := petfinder.NewClient("key")
+	c.RetryPolicy.BaseWait = time.Millisecond
+	c.RetryPolicy.MaxRetryAfter = 5 * time.Millisecond
+	var retries []petfinder.RetryEvent
+	c.RetryPolicy.OnRetry = func(e petfinder.RetryEvent) {
+		retries = append(retries, e)
+	}
+	d := NewDownloader(c, t.TempDir())
+	d.Sizes = []petfinder.PhotoSize{petfinder.PhotoSizeLarge, petfinder.PhotoSizeThumbnail}
+
+	pet := petfinder.Pet{ID: "1"}
+	pet.Media.Photos = []petfinder.Photo{
+		{ID: "1", Size: petfinder.PhotoSizeLarge, URL: ts.URL + "/a.jpg"},
+		{ID: "1", Size: petfinder.PhotoSizeThumbnail, URL: ts.URL + "/flaky.jpg"},
+		{ID: "1", Size: petfinder.PhotoSizeTiny, URL: ts.URL + "/tiny.jpg"},
+		{ID: "2", Size: petfinder.PhotoSizeLarge, URL: ts.URL + "/missing.jpg"},
+	}
+
+	result, err := d.Download(context.Background(), petfinder.Pets{pet})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Downloaded != 2 || result.Skipped != 0 || len(result.Errors) != 1 {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+	if hits["/flaky.jpg"] != 2 {
+		t.Errorf("Expected a retry of the flaky photo, got %d requests", hits["/flaky.jpg"])
+	}
+	if len(retries) != 1 || retries[0].Wait != 5*time.Millisecond {
+		t.Errorf("Expected one retry after the capped Retry-After delay, got %+v", retries)
+	}
+	if hits["/tiny.jpg"] != 0 {
+		t.Errorf("Expected sizes not requested to be skipped")
+	}
+
+	files := result.Manifest.Pets["1"]
+	if len(files) != 2 || files[0].Path != "1/1-pnt.jpg" || files[1].Path != "1/1-x.jpg" {
+		t.Fatalf("Unexpected manifest %+v", files)
+	}
+	buf, err := ioutil.ReadFile(filepath.Join(d.Dir, "1", "1-x.jpg"))
+	if err != nil || string(buf) != "image /a.jpg" {
+		t.Errorf("Unexpected photo contents %q, %v", buf, err)
+	}
+
+	manifest, err := ReadManifest(d.Dir)
+	if err != nil || len(manifest.Pets["1"]) != 2 {
+		t.Fatalf("Unexpected manifest on disk %+v, %v", manifest, err)
+	}
+
+	// a second run finds everything already present
+	result, err = d.Download(context.Background(), petfinder.Pets{pet})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Downloaded != 0 || result.Skipped != 2 || hits["/a.jpg"] != 1 {
+		t.Errorf("Expected stored photos to be skipped, got %+v after %d requests", result, hits["/a.jpg"])
+	}
+
+	// a changed URL is revalidated with the recorded ETag
+	pet.Media.Photos[0].URL = ts.URL + "/a.jpg?bust=2"
+	result, err = d.Download(context.Background(), petfinder.Pets{pet})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Skipped != 2 || hits["/a.jpg"] != 2 {
+		t.Errorf("Expected an unchanged photo to be skipped, got %+v", result)
+	}
+	if files := result.Manifest.Pets["1"]; files[1].URL != pet.Media.Photos[0].URL {
+		t.Errorf("Expected the manifest to record the new URL, got %s", files[1].URL)
+	}
+}
+
+func TestSafeName(t *testing.T) {
+	tests := map[string]string{
+		"123":    "123",
+		"../etc": ".._etc",
+		"..":     "_",
+		"":       "_",
+	}
+	for in, want := range tests {
+		if got := safeName(in); got != want {
+			t.Errorf("safeName(%q) = %q, expected %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package petfinder
+
+import (
+	"strings"
+	"time"
+)
+
+//And matches pets matching every predicate. With no predicates it matches every pet.
+func And(preds ...Predicate) Predicate {
+	return func(p Pet) bool { return matchAll(p, preds) }
+}
+
+//Or matches pets matching any of the predicates. With no predicates it matches no pet.
+func Or(preds ...Predicate) Predicate {
+	return func(p Pet) bool {
+		for _, pred := range preds {
+			if pred(p) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+//Not matches pets not matching the predicate
+func Not(pred Predicate) Predicate {
+	return func(p Pet) bool { return !pred(p) }
+}
+
+//Breed matches pets with any of the given breeds, ignoring case
+func Breed(breeds ...string) Predicate {
+	return func(p Pet) bool {
+		for _, breed := range p.Breeds {
+			for _, b := range breeds {
+				if strings.EqualFold(strings.TrimSpace(breed), strings.TrimSpace(b)) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+//UpdatedSince matches pets whose listing was updated at or after t
+func UpdatedSince(t time.Time) Predicate {
+	return func(p Pet) bool { return !p.LastUpdate.Before(t) }
+}
+
+//HasPhotos matches pets with at least one photo
+func HasPhotos() Predicate {
+	return func(p Pet) bool { return len(p.Media.Photos) > 0 }
+}
+
+//Mix matches mixed breed pets when mixed is true and purebred pets otherwise
+func Mix(mixed bool) Predicate {
+	return func(p Pet) bool { return strings.EqualFold(p.Mix, "yes") == mixed }
+}
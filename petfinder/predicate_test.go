@@ -0,0 +1,42 @@
+package petfinder
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFixturePredicates(t *testing.T) {
+	c, _ := newFixtureClient(t)
+	pets, err := c.FindPet(Options{Location: "75024"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated := pets[0].LastUpdate
+
+	tests := []struct {
+		pred Predicate
+		ids  []string
+	}{
+		{Mix(true), []string{"39930101"}},
+		{Mix(false), []string{"39930102", "39930103"}},
+		{Breed("pit bull terrier", "Beagle"), []string{"39930101", "39930103"}},
+		{Not(Breed("Beagle")), []string{"39930102", "39930103"}},
+		{Or(Mix(true), Breed("Domestic Short Hair")), []string{"39930101", "39930102"}},
+		{And(Altered(), Not(Mix(true))), []string{"39930102"}},
+		{And(), []string{"39930101", "39930102", "39930103"}},
+		{Or(), nil},
+		{HasPhotos(), []string{"39930101", "39930102"}},
+		{UpdatedSince(updated), []string{"39930101", "39930102", "39930103"}},
+		{UpdatedSince(updated.Add(time.Second)), nil},
+	}
+	for i, tt := range tests {
+		var ids []string
+		for _, pet := range pets.Filter(tt.pred) {
+			ids = append(ids, pet.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Errorf("%d: expected %v, got %v", i, tt.ids, ids)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package petfinder
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+//RateLimit configures a RateLimiter. Zero values disable the corresponding limit.
+type RateLimit struct {
+	PerSecond float64 // sustained number of requests per second
+	Burst     int     // maximum number of requests made back to back, defaults to ceil(PerSecond)
+	PerDay    int     // maximum number of requests per day, counted from the first request of the window
+	FailFast  bool    // return an error matching ErrRateLimited instead of blocking until quota is available
+}
+
+//Quota reports the requests a RateLimiter will currently allow
+type Quota struct {
+	Burst    int       // requests that can be made right now without waiting, -1 when unlimited
+	Day      int       // requests left in the current daily window, -1 when unlimited
+	DayReset time.Time // when the daily window resets, zero before the first request
+}
+
+//RateLimiter is a token bucket limiting the rate of requests made by a Client.
+//A single RateLimiter is safe to share across goroutines and Clients.
+type RateLimiter struct {
+	mu       sync.Mutex
+	limit    RateLimit
+	tokens   float64
+	last     time.Time
+	dayStart time.Time
+	dayCount int
+	now      func() time.Time
+}
+
+//NewRateLimiter creates a RateLimiter with a full bucket
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	if limit.Burst <= 0 {
+		limit.Burst = int(math.Ceil(limit.PerSecond))
+	}
+	return &RateLimiter{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		now:    time.Now,
+	}
+}
+
+//Wait takes a token for a single request, blocking until one is available or the context is done.
+//If the limiter fails fast it returns an error matching ErrRateLimited instead of blocking.
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		wait, err := r.reserve()
+		if err != nil || wait == 0 {
+			return err
+		}
+		if err = sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+//reserve takes a token if one is available, otherwise it returns how long to wait for one
+func (r *RateLimiter) reserve() (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.refill(now)
+
+	if r.limit.PerDay > 0 && r.dayCount >= r.limit.PerDay {
+		if r.limit.FailFast {
+			return 0, fmt.Errorf("%w: daily quota of %d requests used", ErrRateLimited, r.limit.PerDay)
+		}
+		return r.dayStart.Add(24 * time.Hour).Sub(now), nil
+	}
+
+	if r.limit.PerSecond > 0 && r.tokens < 1 {
+		if r.limit.FailFast {
+			return 0, fmt.Errorf("%w: %v requests per second", ErrRateLimited, r.limit.PerSecond)
+		}
+		return time.Duration((1 - r.tokens) / r.limit.PerSecond * float64(time.Second)), nil
+	}
+
+	if r.limit.PerSecond > 0 {
+		r.tokens--
+	}
+	if r.dayStart.IsZero() {
+		r.dayStart = now
+	}
+	r.dayCount++
+	return 0, nil
+}
+
+//refill adds the tokens accrued since the last call and rolls over the daily window
+func (r *RateLimiter) refill(now time.Time) {
+	if !r.last.IsZero() && r.limit.PerSecond > 0 {
+		r.tokens += now.Sub(r.last).Seconds() * r.limit.PerSecond
+		if r.tokens > float64(r.limit.Burst) {
+			r.tokens = float64(r.limit.Burst)
+		}
+	}
+	r.last = now
+
+	if !r.dayStart.IsZero() && !now.Before(r.dayStart.Add(24*time.Hour)) {
+		r.dayStart = time.Time{}
+		r.dayCount = 0
+	}
+}
+
+//Remaining reports the quota left without consuming any of it
+func (r *RateLimiter) Remaining() Quota {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.refill(r.now())
+
+	q := Quota{Burst: -1, Day: -1}
+	if r.limit.PerSecond > 0 {
+		q.Burst = int(r.tokens)
+	}
+	if r.limit.PerDay > 0 {
+		q.Day = r.limit.PerDay - r.dayCount
+	}
+	if !r.dayStart.IsZero() {
+		q.DayReset = r.dayStart.Add(24 * time.Hour)
+	}
+	return q
+}
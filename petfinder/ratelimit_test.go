@@ -0,0 +1,64 @@
+package petfinder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := NewRateLimiter(RateLimit{PerSecond: 2, PerDay: 3, FailFast: true})
+	r.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := r.Wait(ctx); err != nil {
+			t.Fatalf("Request %d should be allowed: %v", i, err)
+		}
+	}
+	if err := r.Wait(ctx); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected burst to be exhausted, got %v", err)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if err := r.Wait(ctx); err != nil {
+		t.Errorf("Expected token to be refilled: %v", err)
+	}
+
+	now = now.Add(time.Second)
+	if err := r.Wait(ctx); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected daily quota to be exhausted, got %v", err)
+	}
+	q := r.Remaining()
+	if q.Day != 0 || q.Burst != 2 {
+		t.Errorf("Unexpected remaining quota %+v", q)
+	}
+
+	now = now.Add(24 * time.Hour)
+	if q := r.Remaining(); q.Day != 3 || !q.DayReset.IsZero() {
+		t.Errorf("Expected daily window to reset, got %+v", q)
+	}
+}
+
+func TestRateLimiterBlocks(t *testing.T) {
+	r := NewRateLimiter(RateLimit{PerSecond: 20, Burst: 1})
+
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := r.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected limiter to block for at least 100ms, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := r.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected canceled wait, got %v", err)
+	}
+}
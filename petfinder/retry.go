@@ -0,0 +1,157 @@
+package petfinder
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+//RetryPolicy controls how submitted requests are retried on failure
+type RetryPolicy struct {
+	MaxAttempts int           // total number of attempts including the first, at least one is always made
+	BaseWait    time.Duration // wait before the first retry, doubled on each subsequent retry
+	MaxWait     time.Duration // upper bound of the exponential backoff including jitter
+	Jitter      float64       // fraction of the backoff (0-1) that is randomized
+
+	MaxRetryAfter time.Duration // upper bound of a server requested Retry-After delay, MaxWait if unset
+
+	RetryableStatus []int // HTTP status codes that are retried
+	RetryableCodes  []int // Petfinder API status codes that are retried
+
+	OnRetry func(RetryEvent) // called before sleeping ahead of each retry
+}
+
+//RetryEvent describes a failed attempt that is about to be retried
+type RetryEvent struct {
+	Method  string
+	Attempt int // attempt that failed, starting at 1
+	Wait    time.Duration
+	Err     error
+}
+
+//DefaultRetryPolicy returns the retry policy used by NewClient
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		BaseWait:    600 * time.Millisecond,
+		MaxWait:     5 * time.Second,
+
+		MaxRetryAfter: time.Minute,
+
+		RetryableStatus: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableCodes: []int{StatusInternal},
+	}
+}
+
+//Backoff returns the wait before the given retry, starting at 0, for callers
+//retrying requests of their own under the same policy. It never exceeds MaxWait.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	wait := time.Duration(math.Pow(2, float64(retry)) * float64(p.BaseWait))
+	if p.MaxWait > 0 && wait > p.MaxWait {
+		wait = p.MaxWait
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1) * float64(wait)
+		wait = time.Duration(float64(wait) - jitter + rand.Float64()*2*jitter)
+	}
+	if p.MaxWait > 0 && wait > p.MaxWait {
+		wait = p.MaxWait
+	}
+	return wait
+}
+
+//Delay returns the wait before the given retry, honouring a delay requested by the
+//server with Retry-After up to MaxRetryAfter (MaxWait if unset)
+func (p RetryPolicy) Delay(retry int, retryAfter time.Duration) time.Duration {
+	if retryAfter <= 0 {
+		return p.Backoff(retry)
+	}
+	limit := p.MaxRetryAfter
+	if limit <= 0 {
+		limit = p.MaxWait
+	}
+	if limit > 0 && retryAfter > limit {
+		return limit
+	}
+	return retryAfter
+}
+
+//Do calls attempt until it succeeds, returns an error it reports as not retryable or
+//MaxAttempts is reached, sleeping between attempts as requests submitted by a Client do.
+//attempt is given the attempt number starting at 1 and reports whether its error may be
+//retried along with any delay requested by the server. OnRetry is called with the given
+//name as the method. The last error is returned, or the context's error if it ended while sleeping.
+func (p RetryPolicy) Do(ctx context.Context, name string, attempt func(i int) (retry bool, retryAfter time.Duration, err error)) error {
+	return p.do(ctx, name, attempt, nil)
+}
+
+//do is Do with an additional hook called after OnRetry ahead of each retry
+func (p RetryPolicy) do(ctx context.Context, name string, attempt func(int) (bool, time.Duration, error), onRetry func(RetryEvent)) error {
+	for i := 1; ; i++ {
+		retry, retryAfter, err := attempt(i)
+		if err == nil || !retry || i >= p.MaxAttempts {
+			return err
+		}
+
+		event := RetryEvent{Method: name, Attempt: i, Wait: p.Delay(i-1, retryAfter), Err: err}
+		if p.OnRetry != nil {
+			p.OnRetry(event)
+		}
+		if onRetry != nil {
+			onRetry(event)
+		}
+		if err = sleepContext(ctx, event.Wait); err != nil {
+			return err
+		}
+	}
+}
+
+//ShouldRetryStatus reports whether a response with the HTTP status code is retried
+func (p RetryPolicy) ShouldRetryStatus(status int) bool {
+	return containsInt(p.RetryableStatus, status)
+}
+
+func (p RetryPolicy) retryableCode(code int) bool {
+	return containsInt(p.RetryableCodes, code)
+}
+
+func containsInt(s []int, v int) bool {
+	for _, i := range s {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+//ParseRetryAfter parses a Retry-After header given in seconds or as an HTTP date,
+//returning 0 if it is missing or malformed
+func ParseRetryAfter(value string) time.Duration {
+	return parseRetryAfter(value, time.Now())
+}
+
+//parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
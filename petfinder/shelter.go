@@ -1,49 +1,50 @@
 package petfinder
 
 import (
+	"bytes"
 	"encoding/json"
 )
 
 type shelterSingle struct {
 	Country struct {
-		T string `json:"$t"`
-	} `json:"country"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"country" xml:"country"`
 	Longitude struct {
-		T string `json:"$t"`
-	} `json:"longitude"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"longitude" xml:"longitude"`
 	Name struct {
-		T string `json:"$t"`
-	} `json:"name"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"name" xml:"name"`
 	Phone struct {
-		T string `json:"$t"`
-	} `json:"phone"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"phone" xml:"phone"`
 	State struct {
-		T string `json:"$t"`
-	} `json:"state"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"state" xml:"state"`
 	Address2 struct {
-		T string `json:"$t"`
-	} `json:"address2"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"address2" xml:"address2"`
 	Email struct {
-		T string `json:"$t"`
-	} `json:"email"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"email" xml:"email"`
 	City struct {
-		T string `json:"$t"`
-	} `json:"city"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"city" xml:"city"`
 	Zip struct {
-		T string `json:"$t"`
-	} `json:"zip"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"zip" xml:"zip"`
 	Fax struct {
-		T string `json:"$t"`
-	} `json:"fax"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"fax" xml:"fax"`
 	Latitude struct {
-		T string `json:"$t"`
-	} `json:"latitude"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"latitude" xml:"latitude"`
 	ID struct {
-		T string `json:"$t"`
-	} `json:"id"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"id" xml:"id"`
 	Address1 struct {
-		T string `json:"$t"`
-	} `json:"address1"`
+		T string `json:"$t" xml:",chardata"`
+	} `json:"address1" xml:"address1"`
 }
 
 type shelterResponse struct {
@@ -56,22 +57,10 @@ type shelterResponse struct {
 type shelterFindResponse struct {
 	Petfinder struct {
 		LastOffset struct {
-			T string `json:"lastOffset"`
-		}
+			T string `json:"$t"`
+		} `json:"lastOffset"`
 		Shelters struct {
-			Shelter shelterSingle `json:"shelter"`
-		} `json:"shelters"`
-		Header header `json:"header"`
-	} `json:"petfinder"`
-}
-
-type shelterFindResponses struct {
-	Petfinder struct {
-		LastOffset struct {
-			T string `json:"lastOffset"`
-		}
-		Shelters struct {
-			Shelter []shelterSingle `json:"shelter"`
+			Shelter json.RawMessage `json:"shelter"`
 		} `json:"shelters"`
 		Header header `json:"header"`
 	} `json:"petfinder"`
@@ -79,19 +68,12 @@ type shelterFindResponses struct {
 
 //Shelter contains all information for a pet shelter
 type Shelter struct {
-	ID        string
-	Name      string
-	Longitude string
-	Latitude  string
-	Address1  string
-	Address2  string
-	City      string
-	State     string
-	Country   string
-	Phone     string
-	Email     string
-	Zip       string
-	Fax       string
+	ID          string
+	Name        string
+	Longitude   string
+	Latitude    string
+	Coordinates *LatLng // parsed from Latitude and Longitude, nil when either is missing or malformed
+	Contact
 }
 
 func (s *Shelter) mapShelterResponse(shelterR shelterSingle) {
@@ -99,6 +81,9 @@ func (s *Shelter) mapShelterResponse(shelterR shelterSingle) {
 	s.Name = shelterR.Name.T
 	s.Longitude = shelterR.Longitude.T
 	s.Latitude = shelterR.Latitude.T
+	if coords, err := ParseLatLng(s.Latitude, s.Longitude); err == nil {
+		s.Coordinates = &coords
+	}
 	s.Address1 = shelterR.Address1.T
 	s.Address2 = shelterR.Address2.T
 	s.City = shelterR.City.T
@@ -125,30 +110,49 @@ func (s *Shelter) UnmarshalJSON(buf []byte) error {
 //Shelters is a slice of shelter
 type Shelters []Shelter
 
-//UnmarshalJSON is a custom unmarshaller for Shelter
+//UnmarshalJSON is a custom unmarshaller for Shelters
 func (s *Shelters) UnmarshalJSON(buf []byte) error {
-	var shelter Shelter
+	shelters, _, err := decodeShelterFind(buf)
+	if err != nil {
+		return err
+	}
+	*s = append(*s, shelters...)
+	return nil
+}
+
+//decodeShelterFind decodes a list of shelters along with the lastOffset reported by the API.
+//The API returns a single object rather than an array when only one shelter matches.
+func decodeShelterFind(buf []byte) (Shelters, int, error) {
 	var shelterFindResp shelterFindResponse
 	err := json.Unmarshal(buf, &shelterFindResp)
 	if err != nil {
-		var shelterFindResps shelterFindResponses
-		err = json.Unmarshal(buf, &shelterFindResps)
-		if err != nil {
-			return err
-		}
-
-		for _, shelterR := range shelterFindResps.Petfinder.Shelters.Shelter {
-			shelter = Shelter{}
-			shelter.mapShelterResponse(shelterR)
-			*s = append(*s, shelter)
-		}
+		return nil, 0, err
+	}
 
-		return nil
+	lastOffset, err := parseOffset(shelterFindResp.Petfinder.LastOffset.T)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	shelter = Shelter{}
-	shelter.mapShelterResponse(shelterFindResp.Petfinder.Shelters.Shelter)
-	*s = append(*s, shelter)
+	var shelterRs []shelterSingle
+	raw := bytes.TrimSpace(shelterFindResp.Petfinder.Shelters.Shelter)
+	switch {
+	case len(raw) == 0 || bytes.Equal(raw, []byte("null")):
+	case raw[0] == '[':
+		err = json.Unmarshal(raw, &shelterRs)
+	default:
+		shelterRs = make([]shelterSingle, 1)
+		err = json.Unmarshal(raw, &shelterRs[0])
+	}
+	if err != nil {
+		return nil, 0, err
+	}
 
-	return nil
+	shelters := make(Shelters, 0, len(shelterRs))
+	for _, shelterR := range shelterRs {
+		shelter := Shelter{}
+		shelter.mapShelterResponse(shelterR)
+		shelters = append(shelters, shelter)
+	}
+	return shelters, lastOffset, nil
 }
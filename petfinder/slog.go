@@ -0,0 +1,40 @@
+//go:build go1.21
+// +build go1.21
+
+package petfinder
+
+import (
+	"context"
+	"log/slog"
+)
+
+//NewSlogLogger returns a Logger emitting events as log/slog records.
+//Failed attempts and decode errors are logged at warn level, everything else at debug.
+func NewSlogLogger(l *slog.Logger) Logger {
+	return LoggerFunc(func(ctx context.Context, e LogEvent) {
+		level := slog.LevelDebug
+		if e.Err != nil || e.Kind == EventRetry {
+			level = slog.LevelWarn
+		}
+		attrs := []slog.Attr{
+			slog.String("method", e.Method),
+			slog.Int("attempt", e.Attempt),
+		}
+		if e.URL != "" {
+			attrs = append(attrs, slog.String("url", e.URL))
+		}
+		if e.Duration > 0 {
+			attrs = append(attrs, slog.Duration("duration", e.Duration))
+		}
+		if e.Wait > 0 {
+			attrs = append(attrs, slog.Duration("wait", e.Wait))
+		}
+		if e.StatusCode != 0 {
+			attrs = append(attrs, slog.Int("status", e.StatusCode))
+		}
+		if e.Err != nil {
+			attrs = append(attrs, slog.Any("err", e.Err))
+		}
+		l.LogAttrs(ctx, level, "petfinder "+string(e.Kind), attrs...)
+	})
+}
@@ -0,0 +1,100 @@
+package petfinder
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+//textList decodes repeated "$t" wrapped values. The API emits a single object instead
+//of an array when there is only one value, and null or an empty object when there are none.
+type textList []string
+
+//UnmarshalJSON is a custom unmarshaller for textList
+func (l *textList) UnmarshalJSON(buf []byte) error {
+	*l = nil
+
+	buf = bytes.TrimSpace(buf)
+	switch {
+	case len(buf) == 0 || bytes.Equal(buf, []byte("null")):
+		return nil
+	case buf[0] == '[':
+		var raws []json.RawMessage
+		if err := json.Unmarshal(buf, &raws); err != nil {
+			return err
+		}
+		for _, raw := range raws {
+			t, ok, err := decodeText(raw)
+			if err != nil {
+				return err
+			}
+			if ok {
+				*l = append(*l, t)
+			}
+		}
+		return nil
+	case buf[0] == '{':
+		t, ok, err := decodeText(buf)
+		if err != nil {
+			return err
+		}
+		if ok {
+			*l = textList{t}
+		}
+		return nil
+	}
+	return fmt.Errorf("Expected object or array of {\"$t\": value}, got %s", buf)
+}
+
+//UnmarshalXML appends the text of a repeated element, e.g. each <option> of <options>
+func (l *textList) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var t string
+	if err := d.DecodeElement(&t, &start); err != nil {
+		return err
+	}
+	if t != "" {
+		*l = append(*l, t)
+	}
+	return nil
+}
+
+//decodeText decodes a single {"$t": value} object.
+//ok is false when the object is null, empty or has a null value.
+func decodeText(buf []byte) (string, bool, error) {
+	buf = bytes.TrimSpace(buf)
+	if bytes.Equal(buf, []byte("null")) {
+		return "", false, nil
+	}
+	if len(buf) == 0 || buf[0] != '{' {
+		return "", false, fmt.Errorf("Expected {\"$t\": value}, got %s", buf)
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &obj); err != nil {
+		return "", false, err
+	}
+	raw, ok := obj["$t"]
+	if !ok {
+		return "", false, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return "", false, err
+	}
+	switch v := v.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return v, true, nil
+	case json.Number:
+		return v.String(), true, nil
+	case bool:
+		return strconv.FormatBool(v), true, nil
+	}
+	return "", false, fmt.Errorf("Expected scalar \"$t\" value, got %s", raw)
+}
@@ -0,0 +1,297 @@
+package petfinder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+//tokenExpiryDelta is how long before expiry an access token is refreshed
+const tokenExpiryDelta = time.Minute
+
+//V2Client is the entrypoint to the Petfinder v2 REST API.
+//It authenticates with OAuth2 client credentials, caching the access token
+//and refreshing it shortly before it expires. Copies of a V2Client share the token.
+type V2Client struct {
+	clientID     string
+	clientSecret string
+	baseURL      string
+	tokens       *tokenCache
+	HTTPClient   *http.Client
+}
+
+//NewV2Client creates a new Petfinder v2 API client with the API key and secret of an application
+func NewV2Client(clientID, clientSecret string) V2Client {
+	return V2Client{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		baseURL:      "https://api.petfinder.com/v2/",
+		tokens:       &tokenCache{now: time.Now},
+		HTTPClient:   &http.Client{},
+	}
+}
+
+//tokenCache holds the current access token, safe for concurrent use
+type tokenCache struct {
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+	now    func() time.Time
+}
+
+type tokenResponse struct {
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	AccessToken string `json:"access_token"`
+}
+
+//token returns a cached access token, requesting a new one when none is cached or it is about to expire
+func (c V2Client) token(ctx context.Context) (string, error) {
+	c.tokens.mu.Lock()
+	defer c.tokens.mu.Unlock()
+
+	if c.tokens.token != "" && c.tokens.now().Before(c.tokens.expiry.Add(-tokenExpiryDelta)) {
+		return c.tokens.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.clientID},
+		"client_secret": {c.clientSecret},
+	}
+	request, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var tokenResp tokenResponse
+	if err = c.do(request, "oauth2/token", &tokenResp); err != nil {
+		return "", err
+	}
+	if tokenResp.AccessToken == "" {
+		return "", fmt.Errorf("petfinder: oauth2/token returned no access token")
+	}
+
+	c.tokens.token = tokenResp.AccessToken
+	c.tokens.expiry = c.tokens.now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	return c.tokens.token, nil
+}
+
+//invalidate drops the cached token if it is still the given one
+func (c V2Client) invalidate(token string) {
+	c.tokens.mu.Lock()
+	defer c.tokens.mu.Unlock()
+	if c.tokens.token == token {
+		c.tokens.token = ""
+	}
+}
+
+//v2Problem is the RFC 7807 problem details body of a v2 error response
+type v2Problem struct {
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+//do sends the request and decodes a successful JSON response into v
+func (c V2Client) do(request *http.Request, path string, v interface{}) error {
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		var problem v2Problem
+		json.Unmarshal(body, &problem)
+		detail := problem.Detail
+		if detail == "" {
+			detail = problem.Title
+		}
+		return &HTTPError{
+			StatusCode: response.StatusCode,
+			Method:     path,
+			RetryAfter: parseRetryAfter(response.Header.Get("Retry-After"), time.Now()),
+			Detail:     detail,
+		}
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+//get performs an authenticated GET request, retrying once with a fresh token if the token was rejected
+func (c V2Client) get(ctx context.Context, path string, q url.Values, v interface{}) error {
+	for i := 0; ; i++ {
+		token, err := c.token(ctx)
+		if err != nil {
+			return err
+		}
+
+		request, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path, nil)
+		if err != nil {
+			return err
+		}
+		request.URL.RawQuery = q.Encode()
+		request.Header.Set("Authorization", "Bearer "+token)
+
+		err = c.do(request, path, v)
+		if httpErr, ok := err.(*HTTPError); ok && httpErr.StatusCode == http.StatusUnauthorized && i == 0 {
+			c.invalidate(token)
+			continue
+		}
+		return err
+	}
+}
+
+//V2AnimalQuery are the search parameters of the v2 animals endpoint
+type V2AnimalQuery struct {
+	Type         string `url:"type,omitempty"`
+	Breed        string `url:"breed,omitempty"`
+	Size         string `url:"size,omitempty"`
+	Gender       string `url:"gender,omitempty"`
+	Age          string `url:"age,omitempty"`
+	Color        string `url:"color,omitempty"`
+	Coat         string `url:"coat,omitempty"`
+	Status       string `url:"status,omitempty"`
+	Name         string `url:"name,omitempty"`
+	Organization string `url:"organization,omitempty"`
+	Location     string `url:"location,omitempty"`
+	Distance     int    `url:"distance,omitempty"`
+	Sort         string `url:"sort,omitempty"`
+	Page         int    `url:"page,omitempty"`
+	Limit        int    `url:"limit,omitempty"`
+}
+
+//V2OrganizationQuery are the search parameters of the v2 organizations endpoint
+type V2OrganizationQuery struct {
+	Name     string `url:"name,omitempty"`
+	Location string `url:"location,omitempty"`
+	Distance int    `url:"distance,omitempty"`
+	State    string `url:"state,omitempty"`
+	Country  string `url:"country,omitempty"`
+	Query    string `url:"query,omitempty"`
+	Sort     string `url:"sort,omitempty"`
+	Page     int    `url:"page,omitempty"`
+	Limit    int    `url:"limit,omitempty"`
+}
+
+//V2Pagination describes the page of results returned by a v2 search
+type V2Pagination struct {
+	CountPerPage int `json:"count_per_page"`
+	TotalCount   int `json:"total_count"`
+	CurrentPage  int `json:"current_page"`
+	TotalPages   int `json:"total_pages"`
+}
+
+//V2AnimalType is an animal type along with the values its animals may have
+type V2AnimalType struct {
+	Name    string   `json:"name"`
+	Coats   []string `json:"coats"`
+	Colors  []string `json:"colors"`
+	Genders []string `json:"genders"`
+}
+
+//Animals searches for animals, returning them as Pets along with the pagination of the results
+func (c V2Client) Animals(ctx context.Context, q V2AnimalQuery) (Pets, V2Pagination, error) {
+	var resp struct {
+		Animals    []v2Animal   `json:"animals"`
+		Pagination V2Pagination `json:"pagination"`
+	}
+	values, err := query.Values(q)
+	if err != nil {
+		return nil, resp.Pagination, err
+	}
+	if err = c.get(ctx, "animals", values, &resp); err != nil {
+		return nil, resp.Pagination, err
+	}
+
+	pets := make(Pets, 0, len(resp.Animals))
+	for _, a := range resp.Animals {
+		pets = append(pets, a.pet())
+	}
+	return pets, resp.Pagination, nil
+}
+
+//Animal retrieves a single animal by ID as a Pet
+func (c V2Client) Animal(ctx context.Context, id int) (Pet, error) {
+	var resp struct {
+		Animal v2Animal `json:"animal"`
+	}
+	if err := c.get(ctx, "animals/"+strconv.Itoa(id), nil, &resp); err != nil {
+		return Pet{}, err
+	}
+	return resp.Animal.pet(), nil
+}
+
+//Organizations searches for organizations, returning them as Shelters along with the pagination of the results
+func (c V2Client) Organizations(ctx context.Context, q V2OrganizationQuery) (Shelters, V2Pagination, error) {
+	var resp struct {
+		Organizations []v2Organization `json:"organizations"`
+		Pagination    V2Pagination     `json:"pagination"`
+	}
+	values, err := query.Values(q)
+	if err != nil {
+		return nil, resp.Pagination, err
+	}
+	if err = c.get(ctx, "organizations", values, &resp); err != nil {
+		return nil, resp.Pagination, err
+	}
+
+	shelters := make(Shelters, 0, len(resp.Organizations))
+	for _, o := range resp.Organizations {
+		shelters = append(shelters, o.shelter())
+	}
+	return shelters, resp.Pagination, nil
+}
+
+//Organization retrieves a single organization by ID as a Shelter
+func (c V2Client) Organization(ctx context.Context, id string) (Shelter, error) {
+	var resp struct {
+		Organization v2Organization `json:"organization"`
+	}
+	if err := c.get(ctx, "organizations/"+url.PathEscape(id), nil, &resp); err != nil {
+		return Shelter{}, err
+	}
+	return resp.Organization.shelter(), nil
+}
+
+//Types lists the animal types known to the API
+func (c V2Client) Types(ctx context.Context) ([]V2AnimalType, error) {
+	var resp struct {
+		Types []V2AnimalType `json:"types"`
+	}
+	err := c.get(ctx, "types", nil, &resp)
+	return resp.Types, err
+}
+
+//Breeds lists the breed names of an animal type, e.g. Dog
+func (c V2Client) Breeds(ctx context.Context, animalType string) (Breeds, error) {
+	var resp struct {
+		Breeds []struct {
+			Name string `json:"name"`
+		} `json:"breeds"`
+	}
+	if err := c.get(ctx, "types/"+url.PathEscape(animalType)+"/breeds", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	breeds := make(Breeds, 0, len(resp.Breeds))
+	for _, b := range resp.Breeds {
+		breeds = append(breeds, b.Name)
+	}
+	return breeds, nil
+}
@@ -0,0 +1,140 @@
+package petfinder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const v2AnimalJSON = `{
+	"id": 120,
+	"organization_id": "NJ333",
+	"type": "Cat",
+	"breeds": {"primary": "Tabby", "secondary": null, "mixed": true},
+	"age": "Young",
+	"gender": "Female",
+	"size": "Medium",
+	"attributes": {"spayed_neutered": true, "house_trained": true, "declawed": false, "special_needs": false, "shots_current": true},
+	"environment": {"children": false, "dogs": null, "cats": true},
+	"name": "Nebula",
+	"description": "Nebula is a shorthaired, shy cat.",
+	"photos": [{"small": "https://photos.example/120/1/small.jpg", "medium": "https://photos.example/120/1/medium.jpg", "large": "https://photos.example/120/1/large.jpg", "full": "https://photos.example/120/1/full.jpg"}],
+	"status": "adoptable",
+	"published_at": "2018-09-04T14:49:09+0000",
+	"contact": {"email": "adopt@example.org", "phone": "555-555-5555", "address": {"city": "Jersey City", "state": "NJ", "postcode": "07097", "country": "US"}}
+}`
+
+func newV2TestServer(t *testing.T, tokenRequests *int) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/oauth2/token" {
+			*tokenRequests++
+			if r.FormValue("grant_type") != "client_credentials" || r.FormValue("client_id") != "id" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprintf(w, `{"token_type":"Bearer","expires_in":3600,"access_token":"token%d"}`, *tokenRequests)
+			return
+		}
+		if r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v2/animals":
+			if r.URL.Query().Get("type") != "cat" {
+				t.Errorf("Unexpected query %s", r.URL.RawQuery)
+			}
+			fmt.Fprintf(w, `{"animals":[%s],"pagination":{"count_per_page":20,"total_count":1,"current_page":1,"total_pages":1}}`, v2AnimalJSON)
+		case "/v2/organizations/NJ333":
+			fmt.Fprint(w, `{"organization":{"id":"NJ333","name":"Hudson Rescue","email":"info@example.org","address":{"address1":"1 Main St","city":"Jersey City","state":"NJ","postcode":"07097","country":"US"}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"type":"https://httpstatus.es/404","status":404,"title":"Not Found","detail":"Not Found"}`)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestV2Client(t *testing.T) {
+	var tokenRequests int
+	ts := newV2TestServer(t, &tokenRequests)
+
+	c := NewV2Client("id", "secret")
+	c.baseURL = ts.URL + "/v2/"
+	ctx := context.Background()
+
+	pets, page, err := c.Animals(ctx, V2AnimalQuery{Type: "cat"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pets) != 1 || page.TotalCount != 1 {
+		t.Fatalf("Unexpected animals %+v %+v", pets, page)
+	}
+	pet := pets[0]
+	if pet.ID != "120" || pet.ShelterID != "NJ333" || pet.Animal != AnimalCat || pet.Sex != SexFemale ||
+		pet.Size != SizeMedium || pet.Status != PetStatusAdoptable || pet.Mix != "yes" {
+		t.Errorf("Unexpected pet %+v", pet)
+	}
+	if fmt.Sprint(pet.Options) != "[altered hasShots housetrained noKids]" {
+		t.Errorf("Unexpected options %v", pet.Options)
+	}
+	if len(pet.Breeds) != 1 || len(pet.Media.Photos) != 3 || pet.Contact.Zip != "07097" {
+		t.Errorf("Unexpected breeds, photos or contact %+v", pet)
+	}
+	if !pet.LastUpdate.Equal(time.Date(2018, 9, 4, 14, 49, 9, 0, time.UTC)) {
+		t.Errorf("Unexpected last update %v", pet.LastUpdate)
+	}
+
+	shelter, err := c.Organization(ctx, "NJ333")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shelter.Name != "Hudson Rescue" || shelter.Zip != "07097" {
+		t.Errorf("Unexpected shelter %+v", shelter)
+	}
+
+	if tokenRequests != 1 {
+		t.Errorf("Expected token to be cached, requested %d times", tokenRequests)
+	}
+
+	_, err = c.Animal(ctx, 404)
+	var httpErr *HTTPError
+	if !errors.Is(err, ErrNotFound) || !errors.As(err, &httpErr) || httpErr.Detail != "Not Found" {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestV2ClientTokenRefresh(t *testing.T) {
+	var tokenRequests int
+	ts := newV2TestServer(t, &tokenRequests)
+
+	now := time.Now()
+	c := NewV2Client("id", "secret")
+	c.baseURL = ts.URL + "/v2/"
+	c.tokens.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, err := c.token(ctx); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(58 * time.Minute)
+	if token, _ := c.token(ctx); token != "token1" {
+		t.Errorf("Expected cached token, got %s", token)
+	}
+	now = now.Add(time.Minute + time.Second)
+	if token, _ := c.token(ctx); token != "token2" {
+		t.Errorf("Expected token to be refreshed before expiry, got %s", token)
+	}
+
+	bad := NewV2Client("other", "secret")
+	bad.baseURL = ts.URL + "/v2/"
+	if _, err := bad.Types(ctx); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected unauthorized error, got %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package petfinder
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//v2Time decodes the timestamps of the v2 API, which use a numeric zone offset without a colon
+type v2Time struct {
+	time.Time
+}
+
+//UnmarshalJSON is a custom unmarshaller for v2Time
+func (t *v2Time) UnmarshalJSON(buf []byte) error {
+	var s string
+	if err := json.Unmarshal(buf, &s); err != nil || s == "" {
+		return err
+	}
+	parsed, err := time.Parse("2006-01-02T15:04:05-0700", s)
+	if err != nil {
+		parsed, err = time.Parse(time.RFC3339, s)
+	}
+	t.Time = parsed
+	return err
+}
+
+type v2Address struct {
+	Address1 string `json:"address1"`
+	Address2 string `json:"address2"`
+	City     string `json:"city"`
+	State    string `json:"state"`
+	Postcode string `json:"postcode"`
+	Country  string `json:"country"`
+}
+
+func (a v2Address) address() Address {
+	return Address{
+		Address1: a.Address1,
+		Address2: a.Address2,
+		City:     a.City,
+		State:    a.State,
+		Zip:      a.Postcode,
+		Country:  a.Country,
+	}
+}
+
+type v2Animal struct {
+	ID             int    `json:"id"`
+	OrganizationID string `json:"organization_id"`
+	Type           string `json:"type"`
+	Breeds         struct {
+		Primary   string `json:"primary"`
+		Secondary string `json:"secondary"`
+		Mixed     bool   `json:"mixed"`
+	} `json:"breeds"`
+	Age        string `json:"age"`
+	Gender     string `json:"gender"`
+	Size       string `json:"size"`
+	Attributes struct {
+		SpayedNeutered bool `json:"spayed_neutered"`
+		HouseTrained   bool `json:"house_trained"`
+		Declawed       bool `json:"declawed"`
+		SpecialNeeds   bool `json:"special_needs"`
+		ShotsCurrent   bool `json:"shots_current"`
+	} `json:"attributes"`
+	Environment struct {
+		Children *bool `json:"children"`
+		Dogs     *bool `json:"dogs"`
+		Cats     *bool `json:"cats"`
+	} `json:"environment"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Photos      []struct {
+		Small  string `json:"small"`
+		Medium string `json:"medium"`
+		Large  string `json:"large"`
+		Full   string `json:"full"`
+	} `json:"photos"`
+	Status          string `json:"status"`
+	StatusChangedAt v2Time `json:"status_changed_at"`
+	PublishedAt     v2Time `json:"published_at"`
+	Contact         struct {
+		Email   string    `json:"email"`
+		Phone   string    `json:"phone"`
+		Address v2Address `json:"address"`
+	} `json:"contact"`
+}
+
+//v2Statuses maps v2 animal statuses onto v1 status codes
+var v2Statuses = map[string]PetStatus{
+	"adoptable": PetStatusAdoptable,
+	"adopted":   PetStatusAdopted,
+}
+
+//pet maps a v2 animal onto the v1 Pet type. Attributes and environment flags are
+//translated into the equivalent v1 option codes, and photo sizes onto the closest v1 sizes.
+func (a v2Animal) pet() Pet {
+	var p Pet
+	p.ID = strconv.Itoa(a.ID)
+	p.ShelterID = a.OrganizationID
+	p.Name = a.Name
+	p.Description = a.Description
+	p.Animal = Animal(normalizeEnum(animalEntries, a.Type))
+	p.Age = Age(normalizeEnum(ageEntries, a.Age))
+	p.Sex = Sex(normalizeEnum(sexEntries, a.Gender))
+	p.Size = Size(normalizeEnum(sizeEntries, a.Size))
+
+	p.Status = PetStatus(a.Status)
+	if status, ok := v2Statuses[strings.ToLower(a.Status)]; ok {
+		p.Status = status
+	}
+
+	p.LastUpdate = a.StatusChangedAt.Time
+	if p.LastUpdate.IsZero() {
+		p.LastUpdate = a.PublishedAt.Time
+	}
+
+	for _, breed := range []string{a.Breeds.Primary, a.Breeds.Secondary} {
+		if breed != "" {
+			p.Breeds = append(p.Breeds, breed)
+		}
+	}
+	p.Mix = "no"
+	if a.Breeds.Mixed {
+		p.Mix = "yes"
+	}
+
+	options := []struct {
+		set  bool
+		code string
+	}{
+		{a.Attributes.SpayedNeutered, "altered"},
+		{a.Attributes.ShotsCurrent, "hasShots"},
+		{a.Attributes.HouseTrained, "housetrained"},
+		{a.Attributes.Declawed, "noClaws"},
+		{a.Attributes.SpecialNeeds, "specialNeeds"},
+		{a.Environment.Children != nil && !*a.Environment.Children, "noKids"},
+		{a.Environment.Dogs != nil && !*a.Environment.Dogs, "noDogs"},
+		{a.Environment.Cats != nil && !*a.Environment.Cats, "noCats"},
+	}
+	for _, o := range options {
+		if o.set {
+			p.Options = append(p.Options, o.code)
+		}
+	}
+	p.Attributes = ParseAttributes(p.Options)
+
+	for i, photo := range a.Photos {
+		id := strconv.Itoa(i + 1)
+		for _, size := range []struct {
+			size PhotoSize
+			url  string
+		}{{PhotoSizeLarge, photo.Full}, {PhotoSizeMedium, photo.Medium}, {PhotoSizeSmall, photo.Small}} {
+			if size.url != "" {
+				p.Media.Photos = append(p.Media.Photos, Photo{Size: size.size, URL: size.url, ID: id})
+			}
+		}
+	}
+
+	p.Contact.Email = a.Contact.Email
+	p.Contact.Phone = a.Contact.Phone
+	p.Contact.Address = a.Contact.Address.address()
+	return p
+}
+
+type v2Organization struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Email   string    `json:"email"`
+	Phone   string    `json:"phone"`
+	Address v2Address `json:"address"`
+}
+
+//shelter maps a v2 organization onto the v1 Shelter type.
+//The v2 API does not report coordinates so Latitude and Longitude are left empty.
+func (o v2Organization) shelter() Shelter {
+	return Shelter{
+		ID:   o.ID,
+		Name: o.Name,
+		Contact: Contact{
+			Address: o.Address.address(),
+			Email:   o.Email,
+			Phone:   o.Phone,
+		},
+	}
+}
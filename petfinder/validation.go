@@ -0,0 +1,236 @@
+package petfinder
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//Reasons an option fails validation
+const (
+	ReasonRequired  = "required"
+	ReasonForbidden = "not accepted"
+	ReasonInvalid   = "invalid"
+)
+
+//FieldError describes a single Options field that failed validation
+type FieldError struct {
+	Field   string   // name of the Options field, e.g. Location
+	Value   string   // offending value, empty when the field is missing
+	Reason  string   // one of ReasonRequired, ReasonForbidden or ReasonInvalid
+	Allowed []string // accepted values for enumerated fields
+	Detail  string   // additional explanation of an invalid value
+}
+
+func (e FieldError) String() string {
+	switch e.Reason {
+	case ReasonRequired:
+		return e.Field + " is required"
+	case ReasonForbidden:
+		return fmt.Sprintf("%s is not accepted (got %q)", e.Field, e.Value)
+	}
+	msg := fmt.Sprintf("%s %q is invalid", e.Field, e.Value)
+	if len(e.Allowed) > 0 {
+		msg += ", must be one of " + strings.Join(e.Allowed, ", ")
+	}
+	if e.Detail != "" {
+		msg += ", " + e.Detail
+	}
+	return msg
+}
+
+//ValidationError lists every Options field rejected for an API method.
+//It matches ErrInvalidArgument with errors.Is.
+type ValidationError struct {
+	Method string
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.String()
+	}
+	return fmt.Sprintf("petfinder: invalid options for %s: %s", e.Method, strings.Join(msgs, "; "))
+}
+
+//Is reports whether target is ErrInvalidArgument
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+//Field returns the error for the named field, if any
+func (e *ValidationError) Field(name string) (FieldError, bool) {
+	for _, f := range e.Fields {
+		if f.Field == name {
+			return f, true
+		}
+	}
+	return FieldError{}, false
+}
+
+//endpointRules declares the options an API method requires and accepts.
+//Any option that is set but not accepted is rejected as forbidden.
+type endpointRules struct {
+	required []string
+	accepted []string
+	outputs  []OutputLevel // accepted Output values, any valid output if empty
+}
+
+var endpoints = map[string]endpointRules{
+	"breed.list": {
+		required: []string{"Animal"},
+		accepted: []string{"Animal"},
+	},
+	"pet.getRandom": {
+		accepted: []string{"Animal", "Breed", "Size", "Sex", "Location", "ShelterID", "Output"},
+	},
+	"pet.get": {
+		required: []string{"ID"},
+		accepted: []string{"ID"},
+	},
+	"pet.find": {
+		required: []string{"Location"},
+		accepted: []string{"Animal", "Breed", "Size", "Sex", "Location", "Age", "Offset", "Count", "Output"},
+		outputs:  []OutputLevel{OutputBasic, OutputFull},
+	},
+	"shelter.find": {
+		required: []string{"Location"},
+		accepted: []string{"Location", "ShelterName", "Offset", "Count"},
+	},
+	"shelter.get": {
+		required: []string{"ID"},
+		accepted: []string{"ID"},
+	},
+	"shelter.listByBreed": {
+		required: []string{"Animal", "Breed"},
+		accepted: []string{"Animal", "Breed", "Offset", "Count"},
+	},
+	"shelter.getPets": {
+		required: []string{"ID"},
+		accepted: []string{"ID", "Status", "Offset", "Count", "Output"},
+	},
+}
+
+//optionField is a single Options field along with whether it was set
+type optionField struct {
+	name  string
+	value string
+	set   bool
+}
+
+func (o Options) fields() []optionField {
+	return []optionField{
+		{"ID", o.ID, o.ID != ""},
+		{"Animal", string(o.Animal), o.Animal != ""},
+		{"Breed", o.Breed, o.Breed != ""},
+		{"Size", string(o.Size), o.Size != ""},
+		{"Sex", string(o.Sex), o.Sex != ""},
+		{"Location", o.Location, o.Location != ""},
+		{"Age", string(o.Age), o.Age != ""},
+		{"Offset", strconv.Itoa(o.Offset), o.Offset != 0},
+		{"Count", strconv.Itoa(o.Count), o.Count != 0},
+		{"Output", string(o.Output), o.Output != ""},
+		{"ShelterID", o.ShelterID, o.ShelterID != ""},
+		{"ShelterName", o.ShelterName, o.ShelterName != ""},
+		{"Status", string(o.Status), o.Status != ""},
+	}
+}
+
+var (
+	zipPattern       = regexp.MustCompile(`^\d{5}(-\d{4})?$`)
+	postalPattern    = regexp.MustCompile(`^[A-Za-z]\d[A-Za-z] ?\d[A-Za-z]\d$`)
+	cityStatePattern = regexp.MustCompile(`^[^,]*[A-Za-z][^,]*,\s*[A-Za-z]{2}$`)
+)
+
+//validLocation reports whether a location looks like a US ZIP code, a Canadian postal code or "City, ST"
+func validLocation(location string) bool {
+	location = strings.TrimSpace(location)
+	return zipPattern.MatchString(location) ||
+		postalPattern.MatchString(location) ||
+		cityStatePattern.MatchString(location)
+}
+
+func enumValues(entries []enumEntry) []string {
+	values := make([]string, len(entries))
+	for i, e := range entries {
+		values[i] = e.value
+	}
+	return values
+}
+
+//validate checks the options against the rules of an API method, returning a
+//ValidationError listing every problem found. Methods without rules only have their values checked.
+func (o Options) validate(apiMethod string) error {
+	var fieldErrs []FieldError
+	rules, hasRules := endpoints[apiMethod]
+
+	for _, f := range o.fields() {
+		if !f.set {
+			if hasRules && containsString(rules.required, f.name) {
+				fieldErrs = append(fieldErrs, FieldError{Field: f.name, Reason: ReasonRequired})
+			}
+			continue
+		}
+		if hasRules && !containsString(rules.accepted, f.name) {
+			fieldErrs = append(fieldErrs, FieldError{Field: f.name, Value: f.value, Reason: ReasonForbidden})
+			continue
+		}
+		if fieldErr, ok := o.checkValue(f, rules); !ok {
+			fieldErrs = append(fieldErrs, fieldErr)
+		}
+	}
+
+	if len(fieldErrs) > 0 {
+		return &ValidationError{Method: apiMethod, Fields: fieldErrs}
+	}
+	return nil
+}
+
+//checkValue validates the value of a field that has been set
+func (o Options) checkValue(f optionField, rules endpointRules) (FieldError, bool) {
+	invalid := FieldError{Field: f.name, Value: f.value, Reason: ReasonInvalid}
+	switch f.name {
+	case "Animal":
+		invalid.Allowed = enumValues(animalEntries)
+		return invalid, o.Animal.Valid()
+	case "Size":
+		invalid.Allowed = enumValues(sizeEntries)
+		return invalid, o.Size.Valid()
+	case "Sex":
+		invalid.Allowed = enumValues(sexEntries)
+		return invalid, o.Sex.Valid()
+	case "Age":
+		invalid.Allowed = enumValues(ageEntries)
+		return invalid, o.Age.Valid()
+	case "Status":
+		invalid.Allowed = enumValues(petStatusEntries)
+		return invalid, o.Status.Valid()
+	case "Output":
+		if len(rules.outputs) > 0 {
+			for _, output := range rules.outputs {
+				invalid.Allowed = append(invalid.Allowed, string(output))
+			}
+			return invalid, containsString(invalid.Allowed, f.value)
+		}
+		invalid.Allowed = enumValues(outputLevelEntries)
+		return invalid, o.Output.Valid()
+	case "Location":
+		invalid.Detail = `expected a ZIP/postal code or "City, ST"`
+		return invalid, validLocation(o.Location)
+	case "Offset", "Count":
+		invalid.Detail = "must not be negative"
+		return invalid, !strings.HasPrefix(f.value, "-")
+	}
+	return invalid, true
+}
+
+func containsString(s []string, v string) bool {
+	for _, i := range s {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
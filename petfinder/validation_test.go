@@ -0,0 +1,53 @@
+package petfinder
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	opt := Options{Location: "nowhere", Animal: "dragon", Count: 5, ID: "1"}
+	err := opt.validate("pet.find")
+
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ValidationError to match ErrInvalidArgument")
+	}
+	if valErr.Method != "pet.find" || len(valErr.Fields) != 3 {
+		t.Fatalf("Unexpected validation error %v", err)
+	}
+
+	if f, ok := valErr.Field("ID"); !ok || f.Reason != ReasonForbidden {
+		t.Errorf("Expected ID to be forbidden, got %+v", f)
+	}
+	if f, ok := valErr.Field("Animal"); !ok || f.Reason != ReasonInvalid || f.Value != "dragon" || len(f.Allowed) != 7 {
+		t.Errorf("Expected Animal to be invalid with allowed values, got %+v", f)
+	}
+	if f, ok := valErr.Field("Location"); !ok || f.Reason != ReasonInvalid {
+		t.Errorf("Expected Location to be malformed, got %+v", f)
+	}
+
+	err = Options{Count: 10}.validate("pet.get")
+	if !errors.As(err, &valErr) || len(valErr.Fields) != 2 {
+		t.Fatalf("Expected missing ID and forbidden Count, got %v", err)
+	}
+	if f, _ := valErr.Field("ID"); f.Reason != ReasonRequired {
+		t.Errorf("Expected ID to be required, got %+v", f)
+	}
+}
+
+func TestValidLocation(t *testing.T) {
+	for _, loc := range []string{"94041", "94041-1234", "K1A 0B1", "k1a0b1", "Mountain View, CA", "St. Louis,MO"} {
+		if !validLocation(loc) {
+			t.Errorf("Expected %q to be a valid location", loc)
+		}
+	}
+	for _, loc := range []string{"9404", "Mountain View", "Mountain View, California", "12345, CA", ""} {
+		if validLocation(loc) {
+			t.Errorf("Expected %q to be an invalid location", loc)
+		}
+	}
+}